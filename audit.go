@@ -0,0 +1,97 @@
+package queen
+
+import (
+	"context"
+	"time"
+)
+
+// AuditEntry is a single operation in the append-only audit log.
+// See Auditor and Queen.History.
+type AuditEntry struct {
+	// Version is the version of the migration the operation was performed on.
+	Version string
+
+	// Name is the name of the migration.
+	Name string
+
+	// Direction is the operation: "up", "down", "baseline", or one of the
+	// repair operations "force" (MarkApplied), "unmark" (MarkPending) and
+	// "rehash" (UpdateChecksum).
+	Direction string
+
+	// Success reports whether the operation succeeded.
+	Success bool
+
+	// Error is the error text of a failed operation. Empty on success.
+	Error string
+
+	// Duration is how long the operation took.
+	Duration time.Duration
+
+	// AppliedBy identifies who performed the operation (OS user or Config.AppliedBy).
+	AppliedBy string
+
+	// Hostname is the host that performed the operation.
+	Hostname string
+
+	// QueenVersion is the version of Queen that performed the operation.
+	QueenVersion string
+
+	// BuildTag is the application build tag configured via Config.BuildTag.
+	BuildTag string
+
+	// ExecutedAt is when the entry was written. Set by the driver.
+	ExecutedAt time.Time
+}
+
+// History returns the audit log of all up, down, baseline and repair
+// (force, unmark, rehash) operations in execution order, including failed
+// ones and rolled back migrations.
+//
+// Returns ErrAuditUnsupported if the driver doesn't implement Auditor.
+func (q *Queen) History(ctx context.Context) ([]AuditEntry, error) {
+	auditor, ok := q.driver.(Auditor)
+	if !ok {
+		return nil, ErrAuditUnsupported
+	}
+
+	if err := q.driver.Init(ctx); err != nil {
+		return nil, err
+	}
+
+	return auditor.GetAudit(ctx)
+}
+
+// audit appends an operation on m to the audit log, if the driver keeps one.
+//
+// The migration outcome is already final at this point, so a failure to write
+// the audit entry is logged rather than returned.
+func (q *Queen) audit(ctx context.Context, m *Migration, direction string, start time.Time, opErr error) {
+	auditor, ok := q.driver.(Auditor)
+	if !ok {
+		return
+	}
+
+	info := q.recordInfo(start)
+	entry := AuditEntry{
+		Version:      m.Version,
+		Name:         m.Name,
+		Direction:    direction,
+		Success:      opErr == nil,
+		Duration:     info.Duration,
+		AppliedBy:    info.AppliedBy,
+		Hostname:     info.Hostname,
+		QueenVersion: info.QueenVersion,
+		BuildTag:     info.BuildTag,
+	}
+	if opErr != nil {
+		entry.Error = opErr.Error()
+	}
+
+	if err := auditor.RecordAudit(ctx, entry); err != nil {
+		q.logger.WarnContext(ctx, "failed to write audit entry",
+			"version", m.Version,
+			"direction", direction,
+			"error", err)
+	}
+}
@@ -27,6 +27,7 @@ import (
 	"database/sql"
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/honeynil/queen"
 	"github.com/spf13/cobra"
@@ -57,7 +58,7 @@ type App struct {
 // Configuration priority:
 //  1. Command-line flags (highest)
 //  2. Environment variables
-//  3. Config file .queen.yaml (lowest, requires --use-config)
+//  3. Config file .queen.yaml (lowest, requires --use-config, --config or --env)
 func Run(register RegisterFunc) {
 	RunWithDB(register, nil)
 }
@@ -79,7 +80,7 @@ func RunWithDB(register RegisterFunc, dbOpener DBOpener) {
 Configuration priority:
   1. Command-line flags (highest)
   2. Environment variables (QUEEN_DRIVER, QUEEN_DSN, etc.)
-  3. Config file .queen.yaml (lowest, requires --use-config)
+  3. Config file .queen.yaml (lowest, requires --use-config, --config or --env)
 
 Examples:
   # Apply all pending migrations
@@ -113,12 +114,17 @@ Examples:
 func (app *App) addGlobalFlags() {
 	flags := app.rootCmd.PersistentFlags()
 
-	flags.StringVar(&app.config.Driver, "driver", "", fmt.Sprintf("Database driver (%s, %s, %s, %s)", DriverPostgres, DriverMySQL, DriverSQLite, DriverClickHouse))
+	flags.StringVar(&app.config.Driver, "driver", "", fmt.Sprintf("Database driver (%s, %s, %s, %s, %s, %s, %s)", DriverPostgres, DriverMySQL, DriverSQLite, DriverClickHouse, DriverMSSQL, DriverCockroachDB, DriverYDB))
 	flags.StringVar(&app.config.DSN, "dsn", "", "Database connection string")
 	flags.StringVar(&app.config.Table, "table", "queen_migrations", "Migration table name")
+	flags.StringVar(&app.config.Schema, "schema", "", "Schema of the migration table (postgres, cockroachdb, mssql)")
+	flags.StringVar(&app.config.Group, "group", "", "Only operate on migrations of this group")
+	flags.StringSliceVar(&app.config.Targets, "targets", nil, "Run on many schemas (with --dsn) or DSNs (without); @file reads one per line")
+	flags.IntVar(&app.config.Concurrency, "concurrency", 4, "Number of targets processed at once with --targets")
 	flags.DurationVar(&app.config.LockTimeout, "timeout", 0, "Lock timeout (e.g. 30m, 1h)")
-	flags.BoolVar(&app.config.UseConfig, "use-config", false, "Enable config file (.queen.yaml)")
-	flags.StringVar(&app.config.Env, "env", "", "Environment from config file (development, staging, production)")
+	flags.BoolVar(&app.config.UseConfig, "use-config", false, "Enable config file (.queen.yaml, searched up to the repository root)")
+	flags.StringVar(&app.config.ConfigPath, "config", "", "Path to the config file (implies --use-config)")
+	flags.StringVar(&app.config.Env, "env", "", "Environment from config file (development, staging, production); implies --use-config")
 	flags.BoolVar(&app.config.UnlockProduction, "unlock-production", false, "Unlock production environment")
 	flags.BoolVar(&app.config.Yes, "yes", false, "Automatic yes to prompts (for CI/CD)")
 	flags.BoolVar(&app.config.JSON, "json", false, "Output in JSON format")
@@ -131,8 +137,15 @@ func (app *App) addCommands() {
 		app.createCmd(),
 		app.upCmd(),
 		app.downCmd(),
+		app.gotoCmd(),
+		app.baselineCmd(),
+		app.forceCmd(),
+		app.unmarkCmd(),
+		app.rehashCmd(),
 		app.resetCmd(),
 		app.statusCmd(),
+		app.historyCmd(),
+		app.lockCmd(),
 		app.validateCmd(),
 		app.versionCmd(),
 		app.planCmd(),
@@ -153,14 +166,27 @@ func (app *App) setupQueen(ctx context.Context) (*queen.Queen, error) {
 		return nil, fmt.Errorf("dsn is required (use --dsn or QUEEN_DSN)")
 	}
 
+	driver, err := app.openDriver(ctx, app.config.DSN, app.config.Schema)
+	if err != nil {
+		return nil, err
+	}
+
+	q := queen.NewWithConfig(driver, app.queenConfig())
+	app.registerFunc(q)
+
+	return q, nil
+}
+
+// openDB opens and pings the database at dsn.
+func (app *App) openDB(ctx context.Context, dsn string) (*sql.DB, error) {
 	var db *sql.DB
 	var err error
 
 	if app.dbOpener != nil {
-		db, err = app.dbOpener(app.config.DSN)
+		db, err = app.dbOpener(dsn)
 	} else {
 		sqlDriverName := getSQLDriverName(app.config.Driver)
-		db, err = sql.Open(sqlDriverName, app.config.DSN)
+		db, err = sql.Open(sqlDriverName, dsn)
 	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to open database: %w", err)
@@ -171,29 +197,60 @@ func (app *App) setupQueen(ctx context.Context) (*queen.Queen, error) {
 		return nil, fmt.Errorf("failed to connect to database: %w", err)
 	}
 
-	driver, err := app.createDriver(db)
+	return db, nil
+}
+
+// openDriver opens the database at dsn and creates the configured driver for it.
+func (app *App) openDriver(ctx context.Context, dsn, schema string) (queen.Driver, error) {
+	db, err := app.openDB(ctx, dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	driver, err := app.createDriverInSchema(db, schema)
 	if err != nil {
 		_ = db.Close()
 		return nil, err
 	}
 
+	return driver, nil
+}
+
+// queenConfig returns the Queen configuration derived from the CLI configuration.
+func (app *App) queenConfig() *queen.Config {
 	queenConfig := &queen.Config{
-		TableName: app.config.Table,
+		TableName:      app.config.Table,
+		FailOnOrphaned: app.config.FailOnMissing,
+		Group:          app.config.Group,
+		Naming:         app.config.Naming,
+		IsolationLevel: app.config.IsolationLevel,
+		SkipLock:       app.config.SkipLock,
+		OutOfOrder:     app.config.OutOfOrder,
 	}
 	if app.config.LockTimeout > 0 {
 		queenConfig.LockTimeout = app.config.LockTimeout
 	}
-
-	q := queen.NewWithConfig(driver, queenConfig)
-	app.registerFunc(q)
-
-	return q, nil
+	return queenConfig
 }
 
 // loadConfig loads configuration from all sources.
 // Priority: flags > env > config file.
 func (app *App) loadConfig() error {
-	if app.config.UseConfig {
+	// QUEEN_CONFIG and QUEEN_ENV select the config file, so they are read first
+	if app.config.ConfigPath == "" {
+		app.config.ConfigPath = os.Getenv("QUEEN_CONFIG")
+	}
+	if app.config.Env == "" {
+		app.config.Env = os.Getenv("QUEEN_ENV")
+	}
+
+	// Settings without a flag value are taken from the environment before the
+	// config file fills the rest
+	if err := app.loadSettingsEnv(); err != nil {
+		return err
+	}
+
+	if app.configFileEnabled() {
 		if err := app.loadConfigFile(); err != nil {
 			return err
 		}
@@ -221,3 +278,31 @@ func (app *App) loadEnv() {
 		}
 	}
 }
+
+// loadSettingsEnv reads QUEEN_SCHEMA, QUEEN_GROUP and QUEEN_LOCK_TIMEOUT
+// for the settings not set by flags.
+func (app *App) loadSettingsEnv() error {
+	if app.config.Schema == "" {
+		if schema := os.Getenv("QUEEN_SCHEMA"); schema != "" {
+			app.config.Schema = schema
+		}
+	}
+
+	if app.config.Group == "" {
+		if group := os.Getenv("QUEEN_GROUP"); group != "" {
+			app.config.Group = group
+		}
+	}
+
+	if app.config.LockTimeout == 0 {
+		if timeout := os.Getenv("QUEEN_LOCK_TIMEOUT"); timeout != "" {
+			d, err := time.ParseDuration(timeout)
+			if err != nil {
+				return fmt.Errorf("invalid QUEEN_LOCK_TIMEOUT: %w", err)
+			}
+			app.config.LockTimeout = d
+		}
+	}
+
+	return nil
+}
@@ -26,6 +26,7 @@ func TestAppGlobalFlags(t *testing.T) {
 		"--driver", "postgres",
 		"--dsn", "postgres://localhost/test",
 		"--table", "custom_migrations",
+		"--group", "billing",
 		"--use-config",
 		"--env", "production",
 		"--unlock-production",
@@ -49,6 +50,9 @@ func TestAppGlobalFlags(t *testing.T) {
 	if app.config.Table != "custom_migrations" {
 		t.Errorf("table = %q, want %q", app.config.Table, "custom_migrations")
 	}
+	if app.config.Group != "billing" {
+		t.Errorf("group = %q, want %q", app.config.Group, "billing")
+	}
 	if !app.config.UseConfig {
 		t.Error("use-config should be true")
 	}
@@ -168,6 +172,10 @@ func TestSubcommandHelp(t *testing.T) {
 			name:   "reset",
 			checks: []string{"Rollback all migrations"},
 		},
+		{
+			name:   "lock",
+			checks: []string{"Inspect or release the migration lock", "status", "release"},
+		},
 	}
 
 	for _, sc := range subcommands {
@@ -0,0 +1,98 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/honeynil/queen"
+	"github.com/spf13/cobra"
+)
+
+func (app *App) baselineCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "baseline <version>",
+		Short: "Mark migrations up to a version as applied without running them",
+		Long: `Mark all registered migrations up to and including the given version as
+applied, without executing them.
+
+Use this when adopting Queen on an existing database whose schema already
+matches those migrations. Already applied migrations are skipped, and
+migrations newer than the version stay pending for the next "up".
+
+Examples:
+  # The production schema already contains everything up to 042
+  migrate baseline 042
+
+  # JSON output, without confirmation (CI/CD)
+  migrate baseline 042 --yes --json`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+			version := args[0]
+
+			operation := fmt.Sprintf("baseline migrations up to version %s", version)
+			if err := app.checkConfirmation(operation); err != nil {
+				return err
+			}
+
+			q, err := app.setupQueen(ctx)
+			if err != nil {
+				return err
+			}
+			defer func() { _ = q.Close() }()
+
+			recorded, err := q.Baseline(ctx, version)
+			if err != nil {
+				return fmt.Errorf("failed to baseline version %s: %w", version, err)
+			}
+
+			if app.config.JSON {
+				return app.outputBaselineJSON(version, recorded)
+			}
+
+			if len(recorded) == 0 {
+				fmt.Printf("✓ Nothing to baseline, migrations up to %s are already applied\n", version)
+				return nil
+			}
+			for _, m := range recorded {
+				fmt.Printf("  ✓ %s  %s\n", m.Version, m.Name)
+			}
+			fmt.Printf("✓ Baselined %d migration(s) up to version %s\n", len(recorded), version)
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+func (app *App) outputBaselineJSON(version string, recorded []*queen.Migration) error {
+	type baselineMigration struct {
+		Version  string `json:"version"`
+		Name     string `json:"name"`
+		Checksum string `json:"checksum"`
+	}
+
+	output := struct {
+		Version  string              `json:"version"`
+		Recorded []baselineMigration `json:"recorded"`
+		Count    int                 `json:"count"`
+	}{
+		Version:  version,
+		Recorded: make([]baselineMigration, 0, len(recorded)),
+		Count:    len(recorded),
+	}
+
+	for _, m := range recorded {
+		output.Recorded = append(output.Recorded, baselineMigration{
+			Version:  m.Version,
+			Name:     m.Name,
+			Checksum: m.Checksum(),
+		})
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(output)
+}
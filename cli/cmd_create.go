@@ -1,40 +1,64 @@
 package cli
 
 import (
+	"errors"
 	"fmt"
+	"go/token"
 	"os"
+	"path/filepath"
 	"strings"
+	"unicode"
 
 	"github.com/honeynil/queen"
 	"github.com/spf13/cobra"
 )
 
 func (app *App) createCmd() *cobra.Command {
-	var migrationType string
+	var (
+		migrationType string
+		templatePath  string
+		bump          string
+		register      bool
+	)
 
 	cmd := &cobra.Command{
 		Use:   "create <name>",
 		Short: "Create a new migration",
 		Long: `Create a new migration file with a template.
 
-This command generates a new migration file in the migrations/ directory
-with a sequential version number and the specified name.
+This command generates a new migration file in the migrations directory
+with the next version number and the specified name. The version follows
+the naming pattern from .queen.yaml: sequential-padded (default), sequential,
+semver (see --bump) or timestamp (UTC, e.g. 20261016153045).
 
 Migration types:
   - sql (default): SQL migration with UpSQL and DownSQL
   - go: Go function migration with UpFunc and DownFunc
 
 The command will:
-  1. Scan migrations/ directory to find the next version number
-  2. Create a new file: migrations/<version>_<name>.go
-  3. Print instructions for adding to register.go
+  1. Scan the migrations directory to find the next version number
+  2. Create a new file: <dir>/<version>_<name>.go
+  3. Add the migration to <dir>/register.go (--register) or print instructions
+
+The directory, package, templates and --register can also be set in the
+create section of .queen.yaml. Templates use text/template with the fields
+.Package, .Version, .Name, .Description, .Variable, .UpFunc and .DownFunc.
 
 Examples:
   # Create SQL migration
   migrate create add_users_table
 
   # Create Go function migration
-  migrate create migrate_user_data --type go`,
+  migrate create migrate_user_data --type go
+
+  # Create in another directory and register it automatically
+  migrate create add_invoices --dir internal/billing/migrations --register
+
+  # Use your own template
+  migrate create add_orders --template templates/migration.sql.tmpl
+
+  # Start a new minor version with the semver naming pattern
+  migrate create add_reports --bump minor`,
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			name := args[0]
@@ -44,36 +68,43 @@ Examples:
 				return fmt.Errorf("invalid migration name: must contain only lowercase letters, numbers, and underscores")
 			}
 
-			// Load config file to get naming pattern
-			if err := app.loadConfigFile(); err != nil {
+			// Load config file to get naming pattern and create settings
+			if err := app.loadCreateConfig(); err != nil {
 				// If config doesn't exist, use default pattern
-				if !os.IsNotExist(err) && err.Error() != "config file not found: .queen.yaml (use --use-config only when config file exists)" {
+				if !errors.Is(err, errConfigNotFound) {
 					return fmt.Errorf("failed to load config: %w", err)
 				}
 			}
 
+			if migrationType != "sql" && migrationType != "go" {
+				return fmt.Errorf("invalid migration type: %s (must be 'sql' or 'go')", migrationType)
+			}
+
+			settings := app.createSettings()
+			if templatePath == "" {
+				templatePath = settings.templateFor(migrationType)
+			}
+			if !cmd.Flags().Changed("register") {
+				register = settings.Register
+			}
+
 			// Determine next version
-			nextVersion, err := app.findNextVersion()
+			nextVersion, err := app.findNextVersion(bump)
 			if err != nil {
 				return err
 			}
 
-			// Generate file
-			filename := fmt.Sprintf("migrations/%s_%s.go", nextVersion, name)
-			variableName := migrationVariableName(nextVersion, name)
-
-			var content string
-			switch migrationType {
-			case "sql":
-				content = generateSQLTemplate(nextVersion, name, variableName)
-			case "go":
-				content = generateGoTemplate(nextVersion, name, variableName)
-			default:
-				return fmt.Errorf("invalid migration type: %s (must be 'sql' or 'go')", migrationType)
+			dir := app.migrationsDir()
+			filename := filepath.Join(dir, fmt.Sprintf("%s_%s.go", nextVersion, name))
+			data := newMigrationTemplateData(app.migrationsPackage(), nextVersion, name)
+
+			content, err := renderMigration(migrationType, templatePath, data)
+			if err != nil {
+				return err
 			}
 
 			// Create migrations directory if it doesn't exist
-			if err := os.MkdirAll("migrations", 0755); err != nil {
+			if err := os.MkdirAll(dir, 0755); err != nil {
 				return fmt.Errorf("failed to create migrations directory: %w", err)
 			}
 
@@ -83,24 +114,89 @@ Examples:
 			}
 
 			// Success message
-			fmt.Printf("✓ Created migration file: %s\n\n", filename)
-			fmt.Println("Next steps:")
+			fmt.Printf("✓ Created migration file: %s\n", filename)
+
+			registerFile := filepath.Join(dir, "register.go")
+			if register {
+				err := addToRegister(registerFile, data)
+				if err == nil {
+					fmt.Printf("✓ Added q.MustAdd(%s) to %s\n", data.Variable, registerFile)
+					return nil
+				}
+				fmt.Printf("⚠️  Could not update %s: %v\n", registerFile, err)
+			}
+
+			fmt.Println("\nNext steps:")
 			fmt.Printf("1. Edit %s and add your migration logic\n", filename)
-			fmt.Println("2. Add this line to migrations/register.go:")
-			fmt.Printf("\n   q.MustAdd(%s)\n\n", variableName)
+			fmt.Printf("2. Add this line to %s:\n", registerFile)
+			fmt.Printf("\n   q.MustAdd(%s)\n\n", data.Variable)
 
 			return nil
 		},
 	}
 
 	cmd.Flags().StringVar(&migrationType, "type", "sql", "Migration type: sql or go")
+	cmd.Flags().StringVar(&app.config.MigrationsDir, "dir", "", "Migrations directory (default: migrations)")
+	cmd.Flags().StringVar(&app.config.Package, "package", "", "Go package of the migrations (default: last element of --dir)")
+	cmd.Flags().StringVar(&templatePath, "template", "", "text/template file used instead of the built-in template")
+	cmd.Flags().StringVar(&bump, "bump", "", "Version part to increment with the semver naming pattern: major, minor or patch (default: patch)")
+	cmd.Flags().BoolVar(&register, "register", false, "Add q.MustAdd(...) to register.go in the migrations directory")
 
 	return cmd
 }
 
+// createSettings returns the create section of the config file, or empty settings.
+func (app *App) createSettings() *CreateConfig {
+	if app.config.configFile == nil || app.config.configFile.Create == nil {
+		return &CreateConfig{}
+	}
+	return app.config.configFile.Create
+}
+
+// templateFor returns the configured template file for the migration type.
+func (cc *CreateConfig) templateFor(migrationType string) string {
+	if migrationType == "go" {
+		return cc.GoTemplate
+	}
+	return cc.SQLTemplate
+}
+
+// migrationsDir returns the migrations directory from --dir, the config file
+// or the default "migrations".
+func (app *App) migrationsDir() string {
+	if app.config.MigrationsDir != "" {
+		return app.config.MigrationsDir
+	}
+	if dir := app.createSettings().Dir; dir != "" {
+		return dir
+	}
+	return "migrations"
+}
+
+// migrationsPackage returns the Go package of generated migrations from
+// --package, the config file or the last element of the migrations directory.
+func (app *App) migrationsPackage() string {
+	if app.config.Package != "" {
+		return app.config.Package
+	}
+	if pkg := app.createSettings().Package; pkg != "" {
+		return pkg
+	}
+
+	dir, err := filepath.Abs(app.migrationsDir())
+	if err != nil {
+		return "migrations"
+	}
+	if pkg := filepath.Base(dir); token.IsIdentifier(pkg) {
+		return pkg
+	}
+	return "migrations"
+}
+
 // findNextVersion scans the migrations directory and returns the next version number
-// based on the naming pattern from config.
-func (app *App) findNextVersion() (string, error) {
+// based on the naming pattern from config. bump selects the part incremented
+// for the semver pattern; it must be empty for other patterns.
+func (app *App) findNextVersion(bump string) (string, error) {
 	// Get naming config
 	namingConfig := app.getNamingConfig()
 
@@ -114,27 +210,20 @@ func (app *App) findNextVersion() (string, error) {
 	}
 
 	// Scan existing migrations
-	existingVersions, err := app.getExistingVersions()
+	existingVersions, err := getExistingVersions(app.migrationsDir())
 	if err != nil {
 		return "", err
 	}
 
-	// If no existing migrations, return first version
-	if len(existingVersions) == 0 {
-		switch namingConfig.Pattern {
-		case queen.NamingPatternSequential:
-			return "1", nil
-		case queen.NamingPatternSequentialPadded:
-			padding := namingConfig.Padding
-			if padding <= 0 {
-				padding = 3
-			}
-			return fmt.Sprintf("%0*d", padding, 1), nil
-		case queen.NamingPatternSemver:
-			return "", fmt.Errorf("semver pattern requires manual version specification, use --version flag")
-		default:
-			return "", fmt.Errorf("unknown naming pattern: %s", namingConfig.Pattern)
+	if bump != "" {
+		if namingConfig.Pattern != queen.NamingPatternSemver {
+			return "", fmt.Errorf("--bump requires the semver naming pattern (current: %s)", namingConfig.Pattern)
+		}
+		part, err := queen.ParseVersionBump(bump)
+		if err != nil {
+			return "", err
 		}
+		return queen.NextSemver(existingVersions, part)
 	}
 
 	// Use naming config to find next version
@@ -142,8 +231,8 @@ func (app *App) findNextVersion() (string, error) {
 }
 
 // getExistingVersions scans the migrations directory and returns all existing version strings.
-func (app *App) getExistingVersions() ([]string, error) {
-	entries, err := os.ReadDir("migrations")
+func getExistingVersions(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
 	if err != nil {
 		if os.IsNotExist(err) {
 			return []string{}, nil
@@ -176,7 +265,8 @@ func (app *App) getExistingVersions() ([]string, error) {
 }
 
 // migrationVariableName generates a Go variable name from version and name.
-// Example: "001", "create_users" -> "Migration001CreateUsers"
+// Example: "001", "create_users" -> "Migration001CreateUsers",
+// "1.3.0", "add_reports" -> "Migration1_3_0AddReports"
 func migrationVariableName(version, name string) string {
 	// Convert snake_case to PascalCase
 	parts := strings.Split(name, "_")
@@ -186,84 +276,18 @@ func migrationVariableName(version, name string) string {
 		}
 	}
 
-	return fmt.Sprintf("Migration%s%s", version, strings.Join(parts, ""))
-}
-
-// generateSQLTemplate generates a SQL migration template.
-func generateSQLTemplate(version, name, variableName string) string {
-	description := strings.ReplaceAll(name, "_", " ")
-
-	return fmt.Sprintf(`package migrations
-
-import "github.com/honeynil/queen"
-
-// %s %s
-var %s = queen.M{
-	Version: "%s",
-	Name:    "%s",
-	UpSQL: `+"`"+`
-		-- Write your migration here
-		-- Example: CREATE TABLE users (id INT PRIMARY KEY, email VARCHAR(255));
-	`+"`"+`,
-	DownSQL: `+"`"+`
-		-- Write your rollback here
-		-- Example: DROP TABLE users;
-	`+"`"+`,
-}
-`, variableName, description, variableName, version, name)
-}
-
-// generateGoTemplate generates a Go function migration template.
-func generateGoTemplate(version, name, variableName string) string {
-	description := strings.ReplaceAll(name, "_", " ")
-	upFuncName := fmt.Sprintf("up%s%s", version, toPascalCase(name))
-	downFuncName := fmt.Sprintf("down%s%s", version, toPascalCase(name))
-
-	return fmt.Sprintf(`package migrations
-
-import (
-	"context"
-	"database/sql"
-
-	"github.com/honeynil/queen"
-)
-
-// %s %s
-var %s = queen.M{
-	Version:        "%s",
-	Name:           "%s",
-	ManualChecksum: "v1", // Update this when you change the function
-	UpFunc:         %s,
-	DownFunc:       %s,
-}
-
-func %s(ctx context.Context, tx *sql.Tx) error {
-	// TODO: Implement your migration logic
-	// Example:
-	// rows, err := tx.QueryContext(ctx, "SELECT id, name FROM users")
-	// if err != nil {
-	//     return err
-	// }
-	// defer rows.Close()
-	//
-	// for rows.Next() {
-	//     var id int
-	//     var name string
-	//     if err := rows.Scan(&id, &name); err != nil {
-	//         return err
-	//     }
-	//     // Process data...
-	// }
-	//
-	// return rows.Err()
-	return nil
+	return fmt.Sprintf("Migration%s%s", versionIdentifier(version), strings.Join(parts, ""))
 }
 
-func %s(ctx context.Context, tx *sql.Tx) error {
-	// TODO: Implement your rollback logic
-	return nil
-}
-`, variableName, description, variableName, version, name, upFuncName, downFuncName, upFuncName, downFuncName)
+// versionIdentifier makes version usable inside a Go identifier.
+// Example: "1.3.0" -> "1_3_0"
+func versionIdentifier(version string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return r
+		}
+		return '_'
+	}, version)
 }
 
 // toPascalCase converts snake_case to PascalCase.
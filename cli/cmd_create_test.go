@@ -1,6 +1,8 @@
 package cli
 
 import (
+	"go/parser"
+	"go/token"
 	"os"
 	"path/filepath"
 	"strings"
@@ -67,7 +69,7 @@ func testFindNextVersion(t *testing.T, setupFiles []string, expectedVersion stri
 		config: &Config{},
 	}
 
-	version, err := app.findNextVersion()
+	version, err := app.findNextVersion("")
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -122,6 +124,8 @@ func TestMigrationVariableName(t *testing.T) {
 		{"010", "add_user_profile", "Migration010AddUserProfile"},
 		{"100", "init", "Migration100Init"},
 		{"001", "a_b_c", "Migration001ABC"},
+		{"1.3.0", "add_reports", "Migration1_3_0AddReports"},
+		{"20261016153045", "init", "Migration20261016153045Init"},
 	}
 
 	for _, tt := range tests {
@@ -158,13 +162,16 @@ func TestToPascalCase(t *testing.T) {
 }
 
 func TestGenerateSQLTemplate(t *testing.T) {
-	template := generateSQLTemplate("001", "create_users", "Migration001CreateUsers")
+	template, err := renderMigration("sql", "", newMigrationTemplateData("migrations", "001", "create_users"))
+	if err != nil {
+		t.Fatal(err)
+	}
 
 	// Check required parts
 	checks := []string{
 		"package migrations",
 		`import "github.com/honeynil/queen"`,
-		"Migration001CreateUsers",
+		"var Migration001CreateUsers = queen.M{",
 		`Version: "001"`,
 		`Name:    "create_users"`,
 		"UpSQL:",
@@ -184,7 +191,10 @@ func TestGenerateSQLTemplate(t *testing.T) {
 }
 
 func TestGenerateGoTemplate(t *testing.T) {
-	template := generateGoTemplate("001", "migrate_data", "Migration001MigrateData")
+	template, err := renderMigration("go", "", newMigrationTemplateData("migrations", "001", "migrate_data"))
+	if err != nil {
+		t.Fatal(err)
+	}
 
 	// Check required parts
 	checks := []string{
@@ -213,3 +223,231 @@ func TestGenerateGoTemplate(t *testing.T) {
 		t.Error("Go template should contain ManualChecksum")
 	}
 }
+
+func TestCreateSettings(t *testing.T) {
+	root := t.TempDir()
+	configYAML := `create:
+  dir: db/migrations
+  sql_template: templates/sql.tmpl
+  register: true
+`
+	if err := os.WriteFile(filepath.Join(root, ".queen.yaml"), []byte(configYAML), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Mkdir(filepath.Join(root, ".git"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	sub := filepath.Join(root, "cmd")
+	if err := os.Mkdir(sub, 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Chdir(sub)
+
+	app := &App{config: &Config{}}
+	if err := app.loadConfigFile(); err != nil {
+		t.Fatalf("loadConfigFile() error = %v", err)
+	}
+
+	if got, want := app.migrationsDir(), filepath.Join(root, "db", "migrations"); got != want {
+		t.Errorf("migrationsDir() = %q, want %q (relative to the config file)", got, want)
+	}
+	if got := app.migrationsPackage(); got != "migrations" {
+		t.Errorf("migrationsPackage() = %q, want migrations", got)
+	}
+	if got, want := app.createSettings().templateFor("sql"), filepath.Join(root, "templates", "sql.tmpl"); got != want {
+		t.Errorf("sql template = %q, want %q", got, want)
+	}
+	if !app.createSettings().Register {
+		t.Error("register should be true")
+	}
+
+	app.config.MigrationsDir = "internal/billing/schema"
+	if got := app.migrationsPackage(); got != "schema" {
+		t.Errorf("migrationsPackage() with --dir = %q, want schema", got)
+	}
+	app.config.Package = "billing"
+	if got := app.migrationsPackage(); got != "billing" {
+		t.Errorf("migrationsPackage() with --package = %q, want billing", got)
+	}
+}
+
+func TestLoadCreateConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "queen.yaml")
+	configYAML := `default_env: production
+naming:
+  pattern: sequential
+create:
+  dir: db/migrations
+production:
+  driver: postgres
+  dsn: ${QUEEN_TEST_PRODUCTION_DSN}
+  password_file: /nonexistent/queen/password
+  require_explicit_unlock: true
+  naming:
+    pattern: sequential-padded
+    padding: 4
+`
+	if err := os.WriteFile(path, []byte(configYAML), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	app := &App{config: &Config{ConfigPath: path, Table: DefaultTableName}}
+	if err := app.loadCreateConfig(); err != nil {
+		t.Fatalf("loadCreateConfig() error = %v, want the production environment not to be resolved", err)
+	}
+	if app.config.DSN != "" {
+		t.Errorf("dsn = %q, want empty", app.config.DSN)
+	}
+	if got := app.getNamingConfig(); got == nil || got.Pattern != queen.NamingPatternSequentialPadded || got.Padding != 4 {
+		t.Errorf("getNamingConfig() = %+v, want the production naming", got)
+	}
+	if got, want := app.migrationsDir(), filepath.Join(filepath.Dir(path), "db", "migrations"); got != want {
+		t.Errorf("migrationsDir() = %q, want %q", got, want)
+	}
+
+	app = &App{config: &Config{ConfigPath: path, Table: DefaultTableName}}
+	if err := app.loadConfigFile(); err == nil || !strings.Contains(err.Error(), "--unlock-production") {
+		t.Errorf("loadConfigFile() error = %v, want --unlock-production required", err)
+	}
+}
+
+func TestRenderMigrationCustomTemplate(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "sql.tmpl")
+	text := "package {{.Package}}\n\n// {{.Description}}\nvar {{.Variable}} = queen.M{Version: \"{{.Version}}\", Name: \"{{.Name}}\"}\n"
+	if err := os.WriteFile(path, []byte(text), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := renderMigration("sql", path, newMigrationTemplateData("schema", "007", "add_orders"))
+	if err != nil {
+		t.Fatalf("renderMigration() error = %v", err)
+	}
+	want := "package schema\n\n// add orders\nvar Migration007AddOrders = queen.M{Version: \"007\", Name: \"add_orders\"}\n"
+	if got != want {
+		t.Errorf("renderMigration() = %q, want %q", got, want)
+	}
+
+	if err := os.WriteFile(path, []byte("{{.Unknown}}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := renderMigration("sql", path, newMigrationTemplateData("schema", "007", "add_orders")); err == nil {
+		t.Error("renderMigration() with unknown field should fail")
+	}
+}
+
+func TestAddToRegister(t *testing.T) {
+	t.Run("creates register.go", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "register.go")
+
+		if err := addToRegister(path, newMigrationTemplateData("schema", "001", "create_users")); err != nil {
+			t.Fatalf("addToRegister() error = %v", err)
+		}
+		if err := addToRegister(path, newMigrationTemplateData("schema", "002", "add_email")); err != nil {
+			t.Fatalf("addToRegister() error = %v", err)
+		}
+
+		content, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := "package schema\n\nimport \"github.com/honeynil/queen\"\n\n// Register adds all migrations to q.\n" +
+			"func Register(q *queen.Queen) {\n\tq.MustAdd(Migration001CreateUsers)\n\tq.MustAdd(Migration002AddEmail)\n}\n"
+		if string(content) != want {
+			t.Errorf("register.go =\n%s\nwant\n%s", content, want)
+		}
+	})
+
+	t.Run("appends to existing function", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "register.go")
+		src := "package migrations\n\nimport \"github.com/honeynil/queen\"\n\nfunc All(m *queen.Queen) {}\n\nfunc helper() {}\n"
+		if err := os.WriteFile(path, []byte(src), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := addToRegister(path, newMigrationTemplateData("migrations", "003", "init")); err != nil {
+			t.Fatalf("addToRegister() error = %v", err)
+		}
+
+		content, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(string(content), "func All(m *queen.Queen) {\n\tm.MustAdd(Migration003Init)\n}") {
+			t.Errorf("register.go =\n%s", content)
+		}
+	})
+
+	t.Run("no register function", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "register.go")
+		if err := os.WriteFile(path, []byte("package migrations\n\nfunc helper() {}\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if err := addToRegister(path, newMigrationTemplateData("migrations", "001", "init")); err == nil {
+			t.Error("addToRegister() without register function should fail")
+		}
+	})
+}
+
+func TestFindNextVersionPatterns(t *testing.T) {
+	dir := t.TempDir()
+	for _, f := range []string{"1.0.0_init.go", "1.2.0_add_users.go", "register.go"} {
+		if err := os.WriteFile(filepath.Join(dir, f), []byte("package migrations"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	semver := &App{config: &Config{
+		MigrationsDir: dir,
+		Naming:        &queen.NamingConfig{Pattern: queen.NamingPatternSemver},
+	}}
+
+	for bump, want := range map[string]string{"": "1.2.1", "patch": "1.2.1", "minor": "1.3.0", "major": "2.0.0"} {
+		got, err := semver.findNextVersion(bump)
+		if err != nil || got != want {
+			t.Errorf("findNextVersion(%q) = %q, %v; want %q", bump, got, err, want)
+		}
+	}
+	if _, err := semver.findNextVersion("huge"); err == nil {
+		t.Error("findNextVersion() with invalid bump should fail")
+	}
+
+	sequential := &App{config: &Config{MigrationsDir: dir}}
+	if _, err := sequential.findNextVersion("minor"); err == nil {
+		t.Error("--bump without semver pattern should fail")
+	}
+
+	timestamp := &App{config: &Config{
+		MigrationsDir: t.TempDir(),
+		Naming:        &queen.NamingConfig{Pattern: queen.NamingPatternTimestamp},
+	}}
+	got, err := timestamp.findNextVersion("")
+	if err != nil {
+		t.Fatalf("findNextVersion() error = %v", err)
+	}
+	if err := timestamp.config.Naming.Validate(got); err != nil {
+		t.Errorf("timestamp version %q is invalid: %v", got, err)
+	}
+}
+
+func TestRenderMigrationCompiles(t *testing.T) {
+	for _, version := range []string{"001", "1.3.0", "20261016153045"} {
+		for _, migrationType := range []string{"sql", "go"} {
+			t.Run(version+"_"+migrationType, func(t *testing.T) {
+				data := newMigrationTemplateData("migrations", version, "add_reports")
+				content, err := renderMigration(migrationType, "", data)
+				if err != nil {
+					t.Fatalf("renderMigration() error = %v", err)
+				}
+				if _, err := parser.ParseFile(token.NewFileSet(), "migration.go", content, 0); err != nil {
+					t.Errorf("generated migration does not parse: %v\n%s", err, content)
+				}
+
+				register := filepath.Join(t.TempDir(), "register.go")
+				if err := addToRegister(register, data); err != nil {
+					t.Fatalf("addToRegister() error = %v", err)
+				}
+			})
+		}
+	}
+}
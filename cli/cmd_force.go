@@ -0,0 +1,54 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+func (app *App) forceCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "force <version>",
+		Short: "Mark a migration as applied without running it",
+		Long: `Mark a registered migration as applied without executing it.
+
+Use this to repair the tracking table when a migration was applied by hand,
+or half-failed on a database without transactional DDL (MySQL, ClickHouse)
+and was then completed manually. If the migration is "dirty", its dirty
+marker is cleared. The operation runs under the migration lock and is written
+to the audit log.
+
+Examples:
+  # The migration was finished by hand
+  migrate force 042
+
+  # Without confirmation (CI/CD)
+  migrate force 042 --yes`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+			version := args[0]
+
+			operation := fmt.Sprintf("mark migration %s as applied without running it", version)
+			if err := app.checkConfirmation(operation); err != nil {
+				return err
+			}
+
+			q, err := app.setupQueen(ctx)
+			if err != nil {
+				return err
+			}
+			defer func() { _ = q.Close() }()
+
+			if err := q.MarkApplied(ctx, version); err != nil {
+				return fmt.Errorf("failed to mark migration %s as applied: %w", version, err)
+			}
+
+			fmt.Printf("✓ Migration %s marked as applied\n", version)
+			return nil
+		},
+	}
+
+	return cmd
+}
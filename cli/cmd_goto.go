@@ -0,0 +1,52 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+func (app *App) gotoCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "goto <version>",
+		Short: "Migrate to a specific version",
+		Long: `Migrate the database to exactly the given version.
+
+Applies pending migrations up to and including the version, or rolls back
+applied migrations newer than it, whichever is needed. Versions are compared
+using natural sort order.
+
+Examples:
+  # Bring the database to version 042
+  migrate goto 042
+
+  # Same, without confirmation (CI/CD)
+  migrate goto 042 --yes`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+			version := args[0]
+
+			operation := fmt.Sprintf("migrate to version %s", version)
+			if err := app.checkConfirmation(operation); err != nil {
+				return err
+			}
+
+			q, err := app.setupQueen(ctx)
+			if err != nil {
+				return err
+			}
+			defer func() { _ = q.Close() }()
+
+			if err := q.MigrateTo(ctx, version); err != nil {
+				return fmt.Errorf("failed to migrate to version %s: %w", version, err)
+			}
+
+			fmt.Printf("✓ Database is at version %s\n", version)
+			return nil
+		},
+	}
+
+	return cmd
+}
@@ -0,0 +1,163 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/honeynil/queen"
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+)
+
+func (app *App) historyCmd() *cobra.Command {
+	var limit int
+
+	cmd := &cobra.Command{
+		Use:   "history",
+		Short: "Show the audit log of migration operations",
+		Long: `Show the append-only audit log of every up, down, baseline and repair
+(force, unmark, rehash) operation.
+
+Unlike status, the audit log keeps migrations that were applied and later
+rolled back, as well as failed attempts with their error text.
+
+Output format:
+  - Table format (default): human-readable table
+  - JSON format (--json): machine-readable JSON output
+
+Examples:
+  # Show the full history
+  migrate history
+
+  # Show the last 20 operations
+  migrate history --limit 20
+
+  # JSON output
+  migrate history --json`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+
+			q, err := app.setupQueen(ctx)
+			if err != nil {
+				return err
+			}
+			defer func() { _ = q.Close() }()
+
+			entries, err := q.History(ctx)
+			if err != nil {
+				return fmt.Errorf("failed to get migration history: %w", err)
+			}
+
+			if limit > 0 && len(entries) > limit {
+				entries = entries[len(entries)-limit:]
+			}
+
+			if app.config.JSON {
+				return app.outputHistoryJSON(entries)
+			}
+			return app.outputHistoryTable(entries)
+		},
+	}
+
+	cmd.Flags().IntVar(&limit, "limit", 0, "Show only the last N operations (0 = all)")
+
+	return cmd
+}
+
+func (app *App) outputHistoryTable(entries []queen.AuditEntry) error {
+	if len(entries) == 0 {
+		fmt.Println("No migration operations recorded")
+		return nil
+	}
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.Header([]string{"Executed At", "Version", "Name", "Direction", "Outcome", "Duration", "By", "Host", "Error"})
+
+	var failed int
+	for _, e := range entries {
+		outcome := "success"
+		if !e.Success {
+			outcome = "failure"
+			failed++
+		}
+
+		errText := e.Error
+		if len(errText) > 60 {
+			errText = errText[:60] + "..."
+		}
+
+		if err := table.Append([]string{
+			e.ExecutedAt.Format("2006-01-02 15:04:05"),
+			e.Version,
+			e.Name,
+			e.Direction,
+			outcome,
+			e.Duration.Round(time.Millisecond).String(),
+			e.AppliedBy,
+			e.Hostname,
+			errText,
+		}); err != nil {
+			return err
+		}
+	}
+
+	if err := table.Render(); err != nil {
+		return err
+	}
+
+	fmt.Printf("\nSummary: %d operations, %d failed\n", len(entries), failed)
+	return nil
+}
+
+func (app *App) outputHistoryJSON(entries []queen.AuditEntry) error {
+	type historyEntry struct {
+		Version      string    `json:"version"`
+		Name         string    `json:"name"`
+		Direction    string    `json:"direction"`
+		Success      bool      `json:"success"`
+		Error        string    `json:"error,omitempty"`
+		DurationMs   int64     `json:"duration_ms"`
+		AppliedBy    string    `json:"applied_by"`
+		Hostname     string    `json:"hostname"`
+		QueenVersion string    `json:"queen_version"`
+		BuildTag     string    `json:"build_tag"`
+		ExecutedAt   time.Time `json:"executed_at"`
+	}
+
+	output := struct {
+		History []historyEntry `json:"history"`
+		Summary struct {
+			Total  int `json:"total"`
+			Failed int `json:"failed"`
+		} `json:"summary"`
+	}{
+		History: make([]historyEntry, 0, len(entries)),
+	}
+
+	for _, e := range entries {
+		output.History = append(output.History, historyEntry{
+			Version:      e.Version,
+			Name:         e.Name,
+			Direction:    e.Direction,
+			Success:      e.Success,
+			Error:        e.Error,
+			DurationMs:   e.Duration.Milliseconds(),
+			AppliedBy:    e.AppliedBy,
+			Hostname:     e.Hostname,
+			QueenVersion: e.QueenVersion,
+			BuildTag:     e.BuildTag,
+			ExecutedAt:   e.ExecutedAt,
+		})
+		if !e.Success {
+			output.Summary.Failed++
+		}
+	}
+	output.Summary.Total = len(entries)
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(output)
+}
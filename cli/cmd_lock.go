@@ -0,0 +1,195 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/honeynil/queen"
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+)
+
+func (app *App) lockCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "lock",
+		Short: "Inspect or release the migration lock",
+		Long: `Inspect or release the migration lock.
+
+When a deploy dies while holding the lock, other runs wait until it expires
+(table-based locks) or until the holder's connection is closed (session locks).
+Use "lock status" to see who holds it and "lock release --force" to free it.`,
+	}
+
+	cmd.AddCommand(
+		app.lockStatusCmd(),
+		app.lockReleaseCmd(),
+	)
+
+	return cmd
+}
+
+func (app *App) lockStatusCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "status",
+		Short: "Show who holds the migration lock",
+		Long: `Show the holder of the migration lock: owner, host, and when the lock was
+acquired and expires.
+
+The owner is the lock owner ID for table-based locks (ClickHouse, CockroachDB,
+YDB), and the session or connection ID for PostgreSQL advisory locks, MySQL
+named locks and SQL Server application locks.
+
+Examples:
+  migrate lock status
+  migrate lock status --json`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+
+			q, err := app.setupQueen(ctx)
+			if err != nil {
+				return err
+			}
+			defer func() { _ = q.Close() }()
+
+			info, err := q.LockInfo(ctx)
+			if err != nil {
+				return fmt.Errorf("failed to inspect lock: %w", err)
+			}
+
+			if app.config.JSON {
+				return outputLockJSON(info)
+			}
+			return outputLockTable(info)
+		},
+	}
+}
+
+func (app *App) lockReleaseCmd() *cobra.Command {
+	var force bool
+
+	cmd := &cobra.Command{
+		Use:   "release --force",
+		Short: "Forcibly release the migration lock",
+		Long: `Release the migration lock regardless of who holds it.
+
+Table-based locks are deleted. Session locks are released by terminating the
+holder's database session, which requires the corresponding privilege
+(pg_signal_backend, CONNECTION_ADMIN, ALTER ANY CONNECTION).
+
+Make sure the holder is really gone: a migration that is still running
+would lose its lock. The --force flag is required.
+
+Examples:
+  migrate lock release --force`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !force {
+				return fmt.Errorf("refusing to release the lock without --force")
+			}
+
+			ctx := context.Background()
+
+			q, err := app.setupQueen(ctx)
+			if err != nil {
+				return err
+			}
+			defer func() { _ = q.Close() }()
+
+			info, err := q.LockInfo(ctx)
+			if err != nil {
+				return fmt.Errorf("failed to inspect lock: %w", err)
+			}
+			if info == nil {
+				fmt.Println("Lock is not held")
+				return nil
+			}
+
+			if err := outputLockTable(info); err != nil {
+				return err
+			}
+
+			if err := app.checkConfirmation("release the migration lock held by " + describeHolder(info)); err != nil {
+				return err
+			}
+
+			if err := q.ForceUnlock(ctx); err != nil {
+				return err
+			}
+
+			fmt.Println("✓ Lock released")
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&force, "force", false, "Release the lock even if another process holds it")
+
+	return cmd
+}
+
+// describeHolder returns "owner@host" for confirmation prompts.
+func describeHolder(info *queen.LockInfo) string {
+	if info.Host == "" {
+		return info.Owner
+	}
+	return info.Owner + "@" + info.Host
+}
+
+func outputLockTable(info *queen.LockInfo) error {
+	if info == nil {
+		fmt.Println("Lock is not held")
+		return nil
+	}
+
+	formatTime := func(t time.Time) string {
+		if t.IsZero() {
+			return "-"
+		}
+		return t.Local().Format("2006-01-02 15:04:05")
+	}
+
+	expires := formatTime(info.ExpiresAt)
+	if info.ExpiresAt.IsZero() {
+		expires = "on disconnect"
+	} else if info.Expired() {
+		expires += " (expired)"
+	}
+
+	host := info.Host
+	if host == "" {
+		host = "-"
+	}
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.Header([]string{"Key", "Owner", "Host", "Acquired At", "Expires At"})
+	if err := table.Append([]string{
+		info.Key,
+		info.Owner,
+		host,
+		formatTime(info.AcquiredAt),
+		expires,
+	}); err != nil {
+		return err
+	}
+
+	return table.Render()
+}
+
+func outputLockJSON(info *queen.LockInfo) error {
+	output := struct {
+		Locked  bool            `json:"locked"`
+		Expired bool            `json:"expired"`
+		Holder  *queen.LockInfo `json:"holder,omitempty"`
+	}{
+		Locked: info != nil,
+		Holder: info,
+	}
+	if info != nil {
+		output.Expired = info.Expired()
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(output)
+}
@@ -58,10 +58,11 @@ Examples:
 				return fmt.Errorf("failed to generate migration plan: %w", err)
 			}
 
+			transactionalDDL := q.SupportsTransactionalDDL()
 			if app.config.JSON {
-				return app.outputPlanJSON(plans, direction)
+				return app.outputPlanJSON(plans, direction, transactionalDDL)
 			}
-			return app.outputPlanTable(plans, direction)
+			return app.outputPlanTable(plans, direction, transactionalDDL)
 		},
 	}
 
@@ -71,7 +72,7 @@ Examples:
 	return cmd
 }
 
-func (app *App) outputPlanTable(plans []queen.MigrationPlan, direction string) error {
+func (app *App) outputPlanTable(plans []queen.MigrationPlan, direction string, transactionalDDL bool) error {
 	directionLabel := strings.ToUpper(direction)
 	fmt.Printf("Migration Plan (%s)\n", directionLabel)
 	fmt.Println(strings.Repeat("━", 60))
@@ -139,10 +140,14 @@ func (app *App) outputPlanTable(plans []queen.MigrationPlan, direction string) e
 		fmt.Printf("⚠️  %d migration(s) with warnings\n", withWarnings)
 	}
 
+	if !transactionalDDL {
+		fmt.Println("⚠️  Driver does not support transactional DDL: migrations are recorded after they commit")
+	}
+
 	return nil
 }
 
-func (app *App) outputPlanJSON(plans []queen.MigrationPlan, direction string) error {
+func (app *App) outputPlanJSON(plans []queen.MigrationPlan, direction string, transactionalDDL bool) error {
 	var withRollback, withWarnings int
 
 	for _, plan := range plans {
@@ -155,16 +160,18 @@ func (app *App) outputPlanJSON(plans []queen.MigrationPlan, direction string) er
 	}
 
 	output := struct {
-		Direction string                `json:"direction"`
-		Plans     []queen.MigrationPlan `json:"plans"`
-		Summary   struct {
+		Direction        string                `json:"direction"`
+		TransactionalDDL bool                  `json:"transactional_ddl"`
+		Plans            []queen.MigrationPlan `json:"plans"`
+		Summary          struct {
 			Total        int `json:"total"`
 			WithRollback int `json:"with_rollback"`
 			WithWarnings int `json:"with_warnings"`
 		} `json:"summary"`
 	}{
-		Direction: direction,
-		Plans:     plans,
+		Direction:        direction,
+		TransactionalDDL: transactionalDDL,
+		Plans:            plans,
 	}
 
 	output.Summary.Total = len(plans)
@@ -0,0 +1,50 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+func (app *App) rehashCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "rehash <version>",
+		Short: "Update the stored checksum of an applied migration",
+		Long: `Replace the stored checksum of an applied migration with the checksum
+of its current code.
+
+Use this to accept an intentional edit of an applied migration (status
+"modified"), e.g. a comment or formatting change, so validate passes again.
+The operation runs under the migration lock and is written to the audit log.
+
+Examples:
+  # Accept the edited migration 042
+  migrate rehash 042`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+			version := args[0]
+
+			operation := fmt.Sprintf("update the checksum of migration %s", version)
+			if err := app.checkConfirmation(operation); err != nil {
+				return err
+			}
+
+			q, err := app.setupQueen(ctx)
+			if err != nil {
+				return err
+			}
+			defer func() { _ = q.Close() }()
+
+			if err := q.UpdateChecksum(ctx, version); err != nil {
+				return fmt.Errorf("failed to update checksum of migration %s: %w", version, err)
+			}
+
+			fmt.Printf("✓ Checksum of migration %s updated\n", version)
+			return nil
+		},
+	}
+
+	return cmd
+}
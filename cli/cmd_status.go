@@ -5,6 +5,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"slices"
+	"strings"
 
 	"github.com/honeynil/queen"
 	"github.com/olekukonko/tablewriter"
@@ -18,7 +20,10 @@ func (app *App) statusCmd() *cobra.Command {
 		Long: `Show the status of all registered migrations.
 
 This command displays which migrations have been applied, which are pending,
-and whether any applied migrations have been modified.
+and whether any applied migrations have been modified. Migrations recorded in
+the database but no longer registered in code are shown as "missing", and
+migrations interrupted halfway as "dirty". When migrations use groups, rows
+are grouped and the summary is broken down per group.
 
 Output format:
   - Table format (default): human-readable table
@@ -29,10 +34,25 @@ Examples:
   migrate status
 
   # Show status in JSON format
-  migrate status --json`,
+  migrate status --json
+
+  # Show only the billing migrations
+  migrate status --group billing
+
+  # Show a per-tenant summary for several schemas
+  migrate status --dsn "$DSN" --targets tenant_a,tenant_b`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx := context.Background()
 
+			if app.multiTarget() {
+				runner, _, err := app.setupMultiRunner()
+				if err != nil {
+					return err
+				}
+				results, _ := runner.Status(ctx)
+				return app.outputTargets(results)
+			}
+
 			q, err := app.setupQueen(ctx)
 			if err != nil {
 				return err
@@ -52,46 +72,114 @@ Examples:
 	}
 }
 
+// statusSummary counts migrations by status.
+type statusSummary struct {
+	Total      int `json:"total"`
+	Applied    int `json:"applied"`
+	Pending    int `json:"pending"`
+	Modified   int `json:"modified"`
+	OutOfOrder int `json:"out_of_order"`
+	Missing    int `json:"missing"`
+	Dirty      int `json:"dirty"`
+}
+
+func (s *statusSummary) add(status queen.Status) {
+	s.Total++
+	switch status {
+	case queen.StatusApplied:
+		s.Applied++
+	case queen.StatusPending:
+		s.Pending++
+	case queen.StatusModified:
+		s.Modified++
+	case queen.StatusOutOfOrder:
+		s.Pending++
+		s.OutOfOrder++
+	case queen.StatusOrphaned:
+		s.Missing++
+	case queen.StatusDirty:
+		s.Dirty++
+	}
+}
+
+// summarizeStatuses returns the overall summary and, if any migration has
+// a group, a summary per group ("" for ungrouped migrations).
+func summarizeStatuses(statuses []queen.MigrationStatus) (statusSummary, map[string]*statusSummary) {
+	var total statusSummary
+	var groups map[string]*statusSummary
+
+	for _, s := range statuses {
+		total.add(s.Status)
+		if s.Group != "" && groups == nil {
+			groups = make(map[string]*statusSummary)
+		}
+	}
+
+	if groups != nil {
+		for _, s := range statuses {
+			if groups[s.Group] == nil {
+				groups[s.Group] = &statusSummary{}
+			}
+			groups[s.Group].add(s.Status)
+		}
+	}
+
+	return total, groups
+}
+
 func (app *App) outputStatusTable(statuses []queen.MigrationStatus) error {
-	table := tablewriter.NewWriter(os.Stdout)
-	table.Header([]string{"Version", "Name", "Status", "Applied At", "Checksum", "Rollback"})
+	summary, groups := summarizeStatuses(statuses)
 
-	var applied, pending, modified int
+	header := []string{"Version", "Name", "Status", "Applied At", "Checksum", "Rollback"}
+	if groups != nil {
+		header = append([]string{"Group"}, header...)
+
+		// Keep each group's migrations together
+		statuses = slices.Clone(statuses)
+		slices.SortStableFunc(statuses, func(a, b queen.MigrationStatus) int {
+			return strings.Compare(a.Group, b.Group)
+		})
+	}
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.Header(header)
 
 	for _, s := range statuses {
 		rollback := "no"
 		if s.HasRollback {
 			rollback = "yes"
 		}
+		if s.Status == queen.StatusOrphaned {
+			rollback = "-"
+		}
 
 		appliedAt := "-"
 		if s.AppliedAt != nil {
 			appliedAt = s.AppliedAt.Format("2006-01-02 15:04:05")
 		}
 
-		status := s.Status.String()
-		switch s.Status {
-		case queen.StatusApplied:
-			applied++
-		case queen.StatusPending:
-			pending++
-		case queen.StatusModified:
-			modified++
-		}
-
 		checksum := s.Checksum
 		if len(checksum) > 12 {
 			checksum = checksum[:12] + "..."
 		}
 
-		if err := table.Append([]string{
+		row := []string{
 			s.Version,
 			s.Name,
-			status,
+			s.Status.String(),
 			appliedAt,
 			checksum,
 			rollback,
-		}); err != nil {
+		}
+		if groups != nil {
+			group := s.Group
+			if group == "" {
+				group = "-"
+			}
+			row = append([]string{group}, row...)
+		}
+
+		if err := table.Append(row); err != nil {
 			return err
 		}
 	}
@@ -100,45 +188,56 @@ func (app *App) outputStatusTable(statuses []queen.MigrationStatus) error {
 		return err
 	}
 
-	fmt.Printf("\nSummary: %d total, %d applied, %d pending", len(statuses), applied, pending)
-	if modified > 0 {
-		fmt.Printf(", %d modified (⚠️  WARNING)", modified)
+	fmt.Printf("\nSummary: %s\n", summary)
+
+	names := make([]string, 0, len(groups))
+	for name := range groups {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		label := name
+		if label == "" {
+			label = "(no group)"
+		}
+		fmt.Printf("  %s: %s\n", label, groups[name])
 	}
-	fmt.Println()
+
 	return nil
+}
 
+// String formats the summary as "N total, N applied, N pending" followed by warnings.
+func (s statusSummary) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d total, %d applied, %d pending", s.Total, s.Applied, s.Pending)
+	if s.Modified > 0 {
+		fmt.Fprintf(&b, ", %d modified (⚠️  WARNING)", s.Modified)
+	}
+	if s.OutOfOrder > 0 {
+		fmt.Fprintf(&b, ", %d out-of-order (⚠️  WARNING)", s.OutOfOrder)
+	}
+	if s.Missing > 0 {
+		fmt.Fprintf(&b, ", %d missing from code (⚠️  WARNING)", s.Missing)
+	}
+	if s.Dirty > 0 {
+		fmt.Fprintf(&b, ", %d dirty (⚠️  resolve with force or unmark)", s.Dirty)
+	}
+	return b.String()
 }
 
 func (app *App) outputStatusJSON(statuses []queen.MigrationStatus) error {
-	var applied, pending, modified int
-	for _, s := range statuses {
-		switch s.Status {
-		case queen.StatusApplied:
-			applied++
-		case queen.StatusPending:
-			pending++
-		case queen.StatusModified:
-			modified++
-		}
-	}
+	summary, groups := summarizeStatuses(statuses)
 
 	output := struct {
-		Migrations []queen.MigrationStatus `json:"migrations"`
-		Summary    struct {
-			Total    int `json:"total"`
-			Applied  int `json:"applied"`
-			Pending  int `json:"pending"`
-			Modified int `json:"modified"`
-		} `json:"summary"`
+		Migrations []queen.MigrationStatus   `json:"migrations"`
+		Summary    statusSummary             `json:"summary"`
+		Groups     map[string]*statusSummary `json:"groups,omitempty"`
 	}{
 		Migrations: statuses,
+		Summary:    summary,
+		Groups:     groups,
 	}
 
-	output.Summary.Total = len(statuses)
-	output.Summary.Applied = applied
-	output.Summary.Pending = pending
-	output.Summary.Modified = modified
-
 	enc := json.NewEncoder(os.Stdout)
 	enc.SetIndent("", "  ")
 	return enc.Encode(output)
@@ -0,0 +1,47 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/honeynil/queen"
+)
+
+func TestSummarizeStatuses(t *testing.T) {
+	t.Run("ungrouped", func(t *testing.T) {
+		summary, groups := summarizeStatuses([]queen.MigrationStatus{
+			{Version: "001", Status: queen.StatusApplied},
+			{Version: "002", Status: queen.StatusOutOfOrder},
+			{Version: "003", Status: queen.StatusPending},
+		})
+
+		want := statusSummary{Total: 3, Applied: 1, Pending: 2, OutOfOrder: 1}
+		if summary != want {
+			t.Errorf("summary = %+v, want %+v", summary, want)
+		}
+		if groups != nil {
+			t.Errorf("groups = %v, want nil without groups", groups)
+		}
+	})
+
+	t.Run("grouped", func(t *testing.T) {
+		summary, groups := summarizeStatuses([]queen.MigrationStatus{
+			{Version: "001", Status: queen.StatusApplied},
+			{Version: "billing/001", Group: "billing", Status: queen.StatusApplied},
+			{Version: "billing/002", Group: "billing", Status: queen.StatusPending},
+			{Version: "auth/001", Group: "auth", Status: queen.StatusOrphaned},
+		})
+
+		if summary.Total != 4 || summary.Applied != 2 || summary.Pending != 1 || summary.Missing != 1 {
+			t.Errorf("summary = %+v", summary)
+		}
+		if len(groups) != 3 {
+			t.Fatalf("len(groups) = %d, want 3", len(groups))
+		}
+		if got := *groups["billing"]; got != (statusSummary{Total: 2, Applied: 1, Pending: 1}) {
+			t.Errorf("billing = %+v", got)
+		}
+		if got := *groups[""]; got != (statusSummary{Total: 1, Applied: 1}) {
+			t.Errorf("ungrouped = %+v", got)
+		}
+	})
+}
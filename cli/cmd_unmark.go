@@ -0,0 +1,53 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+func (app *App) unmarkCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "unmark <version>",
+		Short: "Mark a migration as pending without rolling it back",
+		Long: `Remove the tracking row of an applied migration without executing its
+down migration, so the next "up" runs it again.
+
+Use this to repair the tracking table when a migration is recorded as applied
+but its changes are not in the database, e.g. after a partial failure on MySQL
+or ClickHouse. Rows of migrations deleted from code ("missing" in status) can
+be removed as well. A "dirty" migration whose changes were not applied is
+marked pending and its dirty marker cleared. The operation runs under the
+migration lock and is written to the audit log.
+
+Examples:
+  # Re-run migration 042 on the next up
+  migrate unmark 042`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+			version := args[0]
+
+			operation := fmt.Sprintf("mark migration %s as pending without rolling it back", version)
+			if err := app.checkConfirmation(operation); err != nil {
+				return err
+			}
+
+			q, err := app.setupQueen(ctx)
+			if err != nil {
+				return err
+			}
+			defer func() { _ = q.Close() }()
+
+			if err := q.MarkPending(ctx, version); err != nil {
+				return fmt.Errorf("failed to mark migration %s as pending: %w", version, err)
+			}
+
+			fmt.Printf("✓ Migration %s marked as pending\n", version)
+			return nil
+		},
+	}
+
+	return cmd
+}
@@ -23,10 +23,20 @@ Examples:
   migrate up
 
   # Apply next 3 migrations
-  migrate up --steps 3`,
+  migrate up --steps 3
+
+  # Apply to every tenant schema listed in tenants.txt, 8 at a time
+  migrate up --dsn "$DSN" --targets @tenants.txt --concurrency 8`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx := context.Background()
 
+			if app.multiTarget() {
+				if steps > 0 {
+					return fmt.Errorf("--steps cannot be used with --targets")
+				}
+				return app.upTargets(ctx)
+			}
+
 			operation := "apply migrations"
 			if steps > 0 {
 				operation = fmt.Sprintf("apply %d migration(s)", steps)
@@ -61,3 +71,18 @@ Examples:
 
 	return cmd
 }
+
+// upTargets applies pending migrations on every --targets entry.
+func (app *App) upTargets(ctx context.Context) error {
+	runner, count, err := app.setupMultiRunner()
+	if err != nil {
+		return err
+	}
+
+	if err := app.checkConfirmation(fmt.Sprintf("apply migrations on %d target(s)", count)); err != nil {
+		return err
+	}
+
+	results, _ := runner.Up(ctx)
+	return app.outputTargets(results)
+}
@@ -2,13 +2,20 @@ package cli
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
 
+	"github.com/honeynil/queen"
 	"github.com/spf13/cobra"
 )
 
 func (app *App) validateCmd() *cobra.Command {
-	return &cobra.Command{
+	var source bool
+
+	cmd := &cobra.Command{
 		Use:   "validate",
 		Short: "Validate migrations",
 		Long: `Validate all registered migrations.
@@ -17,15 +24,44 @@ This command checks for:
   - Duplicate version identifiers
   - Invalid migration definitions
   - Checksum mismatches (applied migrations that have been modified)
+  - Applied migrations missing from code (with --fail-on-missing)
+
+With --source, the migration files are checked instead, without a database:
+  - Duplicate versions (e.g. two branches both created 015_*.go)
+  - Gaps in sequential versions
+  - Version fields that don't match the file name
+  - Migrations not referenced in register.go
 
 If any issues are found, the command will exit with an error.
 
 Examples:
   # Validate all migrations
-  migrate validate`,
+  migrate validate
+
+  # Also fail if the database has migrations that were deleted from code
+  migrate validate --fail-on-missing
+
+  # Validate every database listed in dsns.txt
+  migrate validate --targets @dsns.txt
+
+  # Check the migration files in CI, with a JSON report
+  migrate validate --source --dir internal/db/migrations --json`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx := context.Background()
 
+			if source {
+				return app.validateSource()
+			}
+
+			if app.multiTarget() {
+				runner, _, err := app.setupMultiRunner()
+				if err != nil {
+					return err
+				}
+				results, _ := runner.Validate(ctx)
+				return app.outputTargets(results)
+			}
+
 			q, err := app.setupQueen(ctx)
 			if err != nil {
 				return err
@@ -40,4 +76,71 @@ Examples:
 			return nil
 		},
 	}
+
+	cmd.Flags().BoolVar(&app.config.FailOnMissing, "fail-on-missing", false, "Fail if applied migrations are not registered in code")
+	cmd.Flags().BoolVar(&source, "source", false, "Check the migration files instead of the database")
+	cmd.Flags().StringVar(&app.config.MigrationsDir, "dir", "", "Migrations directory for --source (default: migrations)")
+
+	return cmd
+}
+
+// sourceReport is the --json output of validate --source.
+type sourceReport struct {
+	Dir    string              `json:"dir"`
+	Valid  bool                `json:"valid"`
+	Issues []queen.SourceIssue `json:"issues"`
+}
+
+// validateSource checks the migration files in the migrations directory.
+func (app *App) validateSource() error {
+	// The config file is optional, it only provides the naming pattern and directory.
+	// The environment is not resolved, so no DSN, secrets or --unlock-production are needed.
+	if err := app.loadCreateConfig(); err != nil && !errors.Is(err, errConfigNotFound) {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	dir := app.migrationsDir()
+	report := sourceReport{Dir: dir, Valid: true, Issues: []queen.SourceIssue{}}
+
+	err := queen.ValidateSource(os.DirFS(dir), ".", app.getNamingConfig())
+	var sourceErr *queen.SourceError
+	switch {
+	case errors.As(err, &sourceErr):
+		report.Valid = false
+		for _, issue := range sourceErr.Issues {
+			issue.Files = prefixPaths(dir, issue.Files)
+			report.Issues = append(report.Issues, issue)
+		}
+	case err != nil:
+		return err
+	}
+
+	if app.config.JSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(report); err != nil {
+			return err
+		}
+	} else if report.Valid {
+		fmt.Printf("✓ All migration files in %s are valid\n", dir)
+	} else {
+		fmt.Printf("✗ Found %d issue(s) in %s:\n", len(report.Issues), dir)
+		for _, issue := range report.Issues {
+			fmt.Printf("  %-12s %s\n", issue.Kind, issue.Message)
+		}
+	}
+
+	if !report.Valid {
+		return fmt.Errorf("validation failed: %w", queen.ErrInvalidSource)
+	}
+	return nil
+}
+
+// prefixPaths makes paths relative to dir relative to the working directory.
+func prefixPaths(dir string, paths []string) []string {
+	prefixed := make([]string, len(paths))
+	for i, p := range paths {
+		prefixed[i] = filepath.Join(dir, p)
+	}
+	return prefixed
 }
@@ -0,0 +1,57 @@
+package cli
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/honeynil/queen"
+)
+
+func TestValidateSource(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "migrations")
+	if err := os.Mkdir(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Chdir(filepath.Dir(dir))
+
+	// The production environment can't be resolved on a CI runner without a database
+	configYAML := `default_env: production
+production:
+  driver: postgres
+  dsn: ${QUEEN_TEST_PRODUCTION_DSN}
+  require_explicit_unlock: true
+`
+	if err := os.WriteFile(ConfigFileName, []byte(configYAML), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	writeMigration := func(version, name string) {
+		t.Helper()
+		data := newMigrationTemplateData("migrations", version, name)
+		content, err := renderMigration("sql", "", data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, version+"_"+name+".go"), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	writeMigration("001", "create_users")
+	writeMigration("002", "create_orders")
+
+	app := &App{config: &Config{}}
+	if err := app.validateSource(); err != nil {
+		t.Fatalf("validateSource() error = %v", err)
+	}
+
+	// A second branch created 002 too
+	writeMigration("002", "create_invoices")
+
+	app = &App{config: &Config{JSON: true}}
+	if err := app.validateSource(); !errors.Is(err, queen.ErrInvalidSource) {
+		t.Errorf("validateSource() error = %v, want ErrInvalidSource", err)
+	}
+}
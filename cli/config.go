@@ -1,8 +1,12 @@
 package cli
 
 import (
+	"database/sql"
+	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/honeynil/queen"
@@ -14,88 +18,263 @@ type Config struct {
 	Driver      string        `yaml:"driver"`
 	DSN         string        `yaml:"dsn"`
 	Table       string        `yaml:"table"`
+	Schema      string        `yaml:"schema"`
+	Group       string        `yaml:"group"`
 	LockTimeout time.Duration `yaml:"lock_timeout"`
+	Targets     []string      `yaml:"targets"`
+	Concurrency int           `yaml:"-"`
 
+	// Queen settings from the selected environment of the config file
+	Naming         *queen.NamingConfig    `yaml:"-"`
+	IsolationLevel sql.IsolationLevel     `yaml:"-"`
+	SkipLock       bool                   `yaml:"-"`
+	OutOfOrder     queen.OutOfOrderPolicy `yaml:"-"`
+
+	// create command flags
+	MigrationsDir string `yaml:"-"`
+	Package       string `yaml:"-"`
+
+	ConfigPath       string `yaml:"-"`
 	UseConfig        bool   `yaml:"-"`
 	Env              string `yaml:"-"`
 	UnlockProduction bool   `yaml:"-"`
 	Yes              bool   `yaml:"-"`
 	JSON             bool   `yaml:"-"`
 	Verbose          bool   `yaml:"-"`
+	FailOnMissing    bool   `yaml:"-"`
 
 	configFile *ConfigFile
 }
 
+// ConfigFileName is the name of the config file searched for by --use-config.
+const ConfigFileName = ".queen.yaml"
+
+// errConfigNotFound is returned when no config file is found by the upward search.
+var errConfigNotFound = errors.New("config file not found")
+
 // ConfigFile represents the structure of .queen.yaml
 type ConfigFile struct {
 	ConfigLocked bool                    `yaml:"config_locked"`
+	DefaultEnv   string                  `yaml:"default_env"`
 	Naming       *NamingConfig           `yaml:"naming"`
+	Create       *CreateConfig           `yaml:"create"`
 	Environments map[string]*Environment `yaml:",inline"`
 }
 
 // NamingConfig represents naming pattern configuration in YAML.
 type NamingConfig struct {
-	Pattern string `yaml:"pattern"` // sequential, sequential-padded, semver
+	Pattern string `yaml:"pattern"` // sequential, sequential-padded, semver, timestamp
 	Padding int    `yaml:"padding"` // for sequential-padded
 	Enforce *bool  `yaml:"enforce"` // pointer to distinguish between unset and false
 }
 
+// CreateConfig represents the settings of the create command in YAML.
+// Relative paths are relative to the directory of the config file.
+type CreateConfig struct {
+	Dir         string `yaml:"dir"`          // default: migrations
+	Package     string `yaml:"package"`      // default: last element of dir
+	SQLTemplate string `yaml:"sql_template"` // text/template file for --type sql
+	GoTemplate  string `yaml:"go_template"`  // text/template file for --type go
+	Register    bool   `yaml:"register"`     // add q.MustAdd(...) to register.go
+}
+
+// resolvePaths makes the relative paths of the create settings relative to base.
+func (cc *CreateConfig) resolvePaths(base string) {
+	if cc == nil {
+		return
+	}
+	for _, path := range []*string{&cc.Dir, &cc.SQLTemplate, &cc.GoTemplate} {
+		if *path != "" && !filepath.IsAbs(*path) {
+			*path = filepath.Join(base, *path)
+		}
+	}
+}
+
 // Environment represents a single environment configuration.
+//
+// String values may reference environment variables as ${VAR} or
+// ${VAR:-default}. Secrets can also be read from files with dsn_file
+// (the whole DSN) or password_file (the password inserted into dsn).
 type Environment struct {
 	Driver                string        `yaml:"driver"`
 	DSN                   string        `yaml:"dsn"`
+	DSNFile               string        `yaml:"dsn_file"`
+	PasswordFile          string        `yaml:"password_file"`
 	Table                 string        `yaml:"table"`
+	Schema                string        `yaml:"schema"`
+	Targets               []string      `yaml:"targets"`
 	LockTimeout           time.Duration `yaml:"lock_timeout"`
 	RequireConfirmation   bool          `yaml:"require_confirmation"`
 	RequireExplicitUnlock bool          `yaml:"require_explicit_unlock"`
+
+	// Queen settings for this environment. Naming overrides the top-level
+	// naming and also validates registered migrations, not only create.
+	Naming         *NamingConfig `yaml:"naming"`
+	IsolationLevel string        `yaml:"isolation_level"`
+	SkipLock       bool          `yaml:"skip_lock"`
+	OutOfOrder     string        `yaml:"out_of_order"`
 }
 
-func (app *App) loadConfigFile() error {
-	configPath := ".queen.yaml"
-	if _, err := os.Stat(configPath); os.IsNotExist(err) {
-		return fmt.Errorf("config file not found: .queen.yaml (use --use-config only when config file exists)")
+// configFileEnabled reports whether a config file should be loaded:
+// with --use-config, --config (QUEEN_CONFIG) or --env (QUEEN_ENV).
+func (app *App) configFileEnabled() bool {
+	return app.config.UseConfig || app.config.ConfigPath != "" || app.config.Env != ""
+}
+
+// findConfigFile returns the config file to load: --config if set, otherwise
+// the nearest .queen.yaml in the current directory or its parents, up to the
+// repository root (the directory containing .git).
+func (app *App) findConfigFile() (string, error) {
+	if app.config.ConfigPath != "" {
+		if _, err := os.Stat(app.config.ConfigPath); err != nil {
+			return "", fmt.Errorf("config file not found: %s", app.config.ConfigPath)
+		}
+		return app.config.ConfigPath, nil
+	}
+
+	wd, err := os.Getwd()
+	if err != nil {
+		return "", fmt.Errorf("failed to find config file: %w", err)
+	}
+
+	for dir := wd; ; {
+		path := filepath.Join(dir, ConfigFileName)
+		if _, err := os.Stat(path); err == nil {
+			return path, nil
+		}
+
+		if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
+			break
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			break
+		}
+		dir = parent
+	}
+
+	return "", fmt.Errorf("%w: %s in %s or its parent directories (use --config to set the path)", errConfigNotFound, ConfigFileName, wd)
+}
+
+// readConfigFile finds and parses the config file without applying any of its settings.
+func (app *App) readConfigFile() (*ConfigFile, error) {
+	configPath, err := app.findConfigFile()
+	if err != nil {
+		return nil, err
 	}
 
 	data, err := os.ReadFile(configPath)
 	if err != nil {
-		return fmt.Errorf("failed to read config file: %w", err)
+		return nil, fmt.Errorf("failed to read config file: %w", err)
 	}
 
 	var cf ConfigFile
 	if err := yaml.Unmarshal(data, &cf); err != nil {
-		return fmt.Errorf("failed to parse config file: %w", err)
+		return nil, fmt.Errorf("failed to parse config file: %w", err)
 	}
 
+	cf.Create.resolvePaths(filepath.Dir(configPath))
 	app.config.configFile = &cf
 
 	if cf.ConfigLocked {
-		return fmt.Errorf("config file is locked for safety. Remove 'config_locked: true' or use flags/ENV vars instead")
+		return nil, fmt.Errorf("config file is locked for safety. Remove 'config_locked: true' or use flags/ENV vars instead")
 	}
 
-	if app.config.Env != "" {
-		env, ok := cf.Environments[app.config.Env]
-		if !ok {
-			return fmt.Errorf("environment '%s' not found in config file", app.config.Env)
-		}
+	return &cf, nil
+}
+
+// selectedEnvironment returns the environment chosen with --env, or the
+// default_env of the config file. Returns nil if neither is set.
+func (app *App) selectedEnvironment(cf *ConfigFile) (*Environment, error) {
+	if app.config.Env == "" {
+		app.config.Env = cf.DefaultEnv
+	}
+	if app.config.Env == "" {
+		return nil, nil
+	}
+
+	env, ok := cf.Environments[app.config.Env]
+	if !ok {
+		return nil, fmt.Errorf("environment '%s' not found in config file", app.config.Env)
+	}
+	return env, nil
+}
+
+// loadCreateConfig loads only the naming and create settings of the config
+// file, for commands that work on migration files and never connect to a
+// database (create, validate --source).
+//
+// Unlike loadConfigFile, it doesn't resolve the selected environment:
+// ${VAR} references, dsn_file and password_file are not read and
+// require_explicit_unlock is not enforced.
+func (app *App) loadCreateConfig() error {
+	cf, err := app.readConfigFile()
+	if err != nil {
+		return err
+	}
+
+	env, err := app.selectedEnvironment(cf)
+	if err != nil {
+		return err
+	}
+	if env != nil {
+		app.config.Naming = env.Naming.toQueenNamingConfig()
+	}
+
+	return nil
+}
+
+// loadConfigFile loads the config file and applies the selected environment.
+// Used by commands that connect to a database.
+func (app *App) loadConfigFile() error {
+	cf, err := app.readConfigFile()
+	if err != nil {
+		return err
+	}
+
+	env, err := app.selectedEnvironment(cf)
+	if err != nil {
+		return err
+	}
+
+	if env != nil {
 
 		if env.RequireExplicitUnlock && !app.config.UnlockProduction {
 			return fmt.Errorf("environment '%s' requires --unlock-production flag", app.config.Env)
 		}
 
+		if err := env.expand(); err != nil {
+			return fmt.Errorf("environment '%s': %w", app.config.Env, err)
+		}
+
 		if app.config.Driver == "" {
 			app.config.Driver = env.Driver
 		}
 		if app.config.DSN == "" {
-			app.config.DSN = env.DSN
+			dsn, err := env.resolveDSN()
+			if err != nil {
+				return fmt.Errorf("environment '%s': %w", app.config.Env, err)
+			}
+			app.config.DSN = dsn
 		}
 		if app.config.Table == DefaultTableName && env.Table != "" {
 			app.config.Table = env.Table
 		}
+		if app.config.Schema == "" {
+			app.config.Schema = env.Schema
+		}
+		if len(app.config.Targets) == 0 {
+			app.config.Targets = env.Targets
+		}
 		if app.config.LockTimeout == 0 && env.LockTimeout > 0 {
 			app.config.LockTimeout = env.LockTimeout
 		}
+		if err := app.applyQueenSettings(env); err != nil {
+			return fmt.Errorf("environment '%s': %w", app.config.Env, err)
+		}
 
-		app.config.configFile.Environments = map[string]*Environment{
+		cf.Environments = map[string]*Environment{
 			app.config.Env: env,
 		}
 	}
@@ -103,6 +282,111 @@ func (app *App) loadConfigFile() error {
 	return nil
 }
 
+// applyQueenSettings copies the Queen settings of env into the CLI configuration.
+func (app *App) applyQueenSettings(env *Environment) error {
+	level, err := parseIsolationLevel(env.IsolationLevel)
+	if err != nil {
+		return err
+	}
+
+	policy, err := queen.ParseOutOfOrderPolicy(env.OutOfOrder)
+	if err != nil {
+		return err
+	}
+
+	app.config.Naming = env.Naming.toQueenNamingConfig()
+	app.config.IsolationLevel = level
+	app.config.SkipLock = env.SkipLock
+	app.config.OutOfOrder = policy
+	return nil
+}
+
+// parseIsolationLevel converts an isolation level name such as
+// "read_committed" or "REPEATABLE READ" to sql.IsolationLevel.
+func parseIsolationLevel(s string) (sql.IsolationLevel, error) {
+	name := strings.NewReplacer("_", " ", "-", " ").Replace(strings.ToLower(strings.TrimSpace(s)))
+	if name == "" || name == "default" {
+		return sql.LevelDefault, nil
+	}
+
+	for _, level := range []sql.IsolationLevel{
+		sql.LevelReadUncommitted,
+		sql.LevelReadCommitted,
+		sql.LevelWriteCommitted,
+		sql.LevelRepeatableRead,
+		sql.LevelSnapshot,
+		sql.LevelSerializable,
+		sql.LevelLinearizable,
+	} {
+		if strings.ToLower(level.String()) == name {
+			return level, nil
+		}
+	}
+
+	return sql.LevelDefault, fmt.Errorf("invalid isolation_level %q (e.g. read_committed, repeatable_read, serializable)", s)
+}
+
+// expand replaces ${VAR} references in the environment, except in the DSN
+// settings, which are only expanded by resolveDSN when the DSN is needed.
+func (env *Environment) expand() error {
+	for _, field := range []*string{&env.Driver, &env.Table, &env.Schema} {
+		expanded, err := expandEnv(*field)
+		if err != nil {
+			return err
+		}
+		*field = expanded
+	}
+
+	for i, target := range env.Targets {
+		expanded, err := expandEnv(target)
+		if err != nil {
+			return err
+		}
+		env.Targets[i] = expanded
+	}
+
+	return nil
+}
+
+// resolveDSN returns the DSN of the environment from dsn or dsn_file,
+// with the password from password_file if set.
+func (env *Environment) resolveDSN() (string, error) {
+	if env.DSN != "" && env.DSNFile != "" {
+		return "", fmt.Errorf("dsn and dsn_file are mutually exclusive")
+	}
+
+	dsn, err := expandEnv(env.DSN)
+	if err != nil {
+		return "", err
+	}
+
+	if env.DSNFile != "" {
+		path, err := expandEnv(env.DSNFile)
+		if err != nil {
+			return "", err
+		}
+		if dsn, err = readSecretFile(path); err != nil {
+			return "", err
+		}
+	}
+
+	if env.PasswordFile != "" {
+		path, err := expandEnv(env.PasswordFile)
+		if err != nil {
+			return "", err
+		}
+		password, err := readSecretFile(path)
+		if err != nil {
+			return "", err
+		}
+		if dsn, err = withPassword(dsn, password); err != nil {
+			return "", fmt.Errorf("password_file: %w", err)
+		}
+	}
+
+	return dsn, nil
+}
+
 func (app *App) requiresConfirmation() bool {
 	if app.config.Yes {
 		return false
@@ -146,8 +430,13 @@ func (nc *NamingConfig) toQueenNamingConfig() *queen.NamingConfig {
 	return config
 }
 
-// getNamingConfig returns the naming configuration from the config file.
+// getNamingConfig returns the naming configuration of the selected
+// environment, or the top-level one from the config file.
 func (app *App) getNamingConfig() *queen.NamingConfig {
+	if app.config.Naming != nil {
+		return app.config.Naming
+	}
+
 	if app.config.configFile == nil || app.config.configFile.Naming == nil {
 		return nil
 	}
@@ -45,7 +45,7 @@ func (app *App) checkConfirmation(operation string) error {
 
 	env := app.getEnvironmentName()
 	message := fmt.Sprintf("⚠️  WARNING: You are about to %s on %s environment\nDatabase: %s",
-		operation, strings.ToUpper(env), app.config.DSN)
+		operation, strings.ToUpper(env), redactDSN(app.config.DSN))
 
 	// For production, require exact confirmation
 	if env == "production" {
@@ -3,12 +3,18 @@ package cli
 import (
 	"database/sql"
 	"fmt"
+	"sort"
+	"strings"
+	"sync"
 
 	"github.com/honeynil/queen"
 	"github.com/honeynil/queen/drivers/clickhouse"
+	"github.com/honeynil/queen/drivers/cockroachdb"
+	"github.com/honeynil/queen/drivers/mssql"
 	"github.com/honeynil/queen/drivers/mysql"
 	"github.com/honeynil/queen/drivers/postgres"
 	"github.com/honeynil/queen/drivers/sqlite"
+	"github.com/honeynil/queen/drivers/ydb"
 )
 
 // Driver name constants.
@@ -16,21 +22,154 @@ import (
 // These constants define the recognized driver names that can be used
 // in configuration. Some drivers have multiple aliases for convenience.
 const (
-	DriverPostgres   = "postgres"
-	DriverPostgreSQL = "postgresql"
-	DriverMySQL      = "mysql"
-	DriverSQLite     = "sqlite"
-	DriverSQLite3    = "sqlite3"
-	DriverClickHouse = "clickhouse"
+	DriverPostgres    = "postgres"
+	DriverPostgreSQL  = "postgresql"
+	DriverMySQL       = "mysql"
+	DriverSQLite      = "sqlite"
+	DriverSQLite3     = "sqlite3"
+	DriverClickHouse  = "clickhouse"
+	DriverMSSQL       = "mssql"
+	DriverSQLServer   = "sqlserver"
+	DriverCockroachDB = "cockroachdb"
+	DriverCockroach   = "cockroach"
+	DriverYDB         = "ydb"
 
 	// SQL driver names used with database/sql.
 	// These are the actual driver names registered with sql.Register().
-	SQLDriverPostgres   = "pgx"
-	SQLDriverMySQL      = "mysql"
-	SQLDriverSQLite     = "sqlite3"
-	SQLDriverClickHouse = "clickhouse"
+	SQLDriverPostgres    = "pgx"
+	SQLDriverMySQL       = "mysql"
+	SQLDriverSQLite      = "sqlite3"
+	SQLDriverClickHouse  = "clickhouse"
+	SQLDriverMSSQL       = "sqlserver"
+	SQLDriverCockroachDB = "pgx"
+	SQLDriverYDB         = "ydb"
 )
 
+// DriverFactory creates a Queen driver for an open database connection.
+//
+// tableName is the migrations table name from the configuration (--table).
+type DriverFactory func(db *sql.DB, tableName string) (queen.Driver, error)
+
+// schemaFactory creates a built-in driver whose tables live in schema (--schema).
+type schemaFactory func(db *sql.DB, tableName, schema string) (queen.Driver, error)
+
+var (
+	driversMu     sync.RWMutex
+	drivers       = map[string]DriverFactory{}
+	schemaDrivers = map[string]schemaFactory{}
+)
+
+func init() {
+	registerBuiltinWithSchema(func(db *sql.DB, tableName, schema string) (queen.Driver, error) {
+		return postgres.NewWithTableName(db, tableName, postgres.WithSchema(schema)), nil
+	}, DriverPostgres, DriverPostgreSQL, SQLDriverPostgres)
+
+	registerBuiltin(func(db *sql.DB, tableName string) (queen.Driver, error) {
+		return mysql.NewWithTableName(db, tableName), nil
+	}, DriverMySQL)
+
+	registerBuiltin(func(db *sql.DB, tableName string) (queen.Driver, error) {
+		return sqlite.NewWithTableName(db, tableName), nil
+	}, DriverSQLite, DriverSQLite3)
+
+	registerBuiltin(func(db *sql.DB, tableName string) (queen.Driver, error) {
+		return clickhouse.NewWithTableName(db, tableName)
+	}, DriverClickHouse)
+
+	registerBuiltinWithSchema(func(db *sql.DB, tableName, schema string) (queen.Driver, error) {
+		return mssql.NewWithTableName(db, tableName, mssql.WithSchema(schema)), nil
+	}, DriverMSSQL, DriverSQLServer)
+
+	registerBuiltinWithSchema(func(db *sql.DB, tableName, schema string) (queen.Driver, error) {
+		return cockroachdb.NewWithTableName(db, tableName, cockroachdb.WithSchema(schema))
+	}, DriverCockroachDB, DriverCockroach)
+
+	registerBuiltin(func(db *sql.DB, tableName string) (queen.Driver, error) {
+		return ydb.NewWithTableName(db, tableName)
+	}, DriverYDB)
+}
+
+// registerBuiltin registers a built-in driver factory under all its aliases.
+func registerBuiltin(factory DriverFactory, names ...string) {
+	for _, name := range names {
+		RegisterDriver(name, factory)
+	}
+}
+
+// registerBuiltinWithSchema registers a built-in driver that supports --schema
+// under all its aliases.
+func registerBuiltinWithSchema(factory schemaFactory, names ...string) {
+	registerBuiltin(func(db *sql.DB, tableName string) (queen.Driver, error) {
+		return factory(db, tableName, "")
+	}, names...)
+
+	driversMu.Lock()
+	defer driversMu.Unlock()
+	for _, name := range names {
+		schemaDrivers[name] = factory
+	}
+}
+
+// RegisterDriver makes a driver available to the CLI under the given name,
+// so in-house drivers can be added to a migrate binary without forking the CLI.
+//
+// Call it before Run, typically from main or an init function. Registering
+// an existing name (including a built-in one) replaces its factory.
+//
+// Unless RunWithDB is used, the database is opened with sql.Open(name, dsn),
+// so name should match the database/sql driver name. Registered drivers
+// don't support --schema; put the schema in the driver itself instead.
+//
+// Example:
+//
+//	cli.RegisterDriver("tidb", func(db *sql.DB, table string) (queen.Driver, error) {
+//	    return tidb.NewWithTableName(db, table), nil
+//	})
+//	cli.Run(migrations.Register)
+func RegisterDriver(name string, factory DriverFactory) {
+	if name == "" {
+		panic("cli: RegisterDriver called with empty name")
+	}
+	if factory == nil {
+		panic("cli: RegisterDriver factory is nil for driver " + name)
+	}
+
+	driversMu.Lock()
+	defer driversMu.Unlock()
+	drivers[strings.ToLower(name)] = factory
+	delete(schemaDrivers, strings.ToLower(name))
+}
+
+// lookupSchemaDriver returns the built-in factory registered under name
+// if the driver supports --schema.
+func lookupSchemaDriver(name string) (schemaFactory, bool) {
+	driversMu.RLock()
+	defer driversMu.RUnlock()
+	factory, ok := schemaDrivers[strings.ToLower(name)]
+	return factory, ok
+}
+
+// lookupDriver returns the factory registered under name.
+func lookupDriver(name string) (DriverFactory, bool) {
+	driversMu.RLock()
+	defer driversMu.RUnlock()
+	factory, ok := drivers[strings.ToLower(name)]
+	return factory, ok
+}
+
+// registeredDrivers returns the sorted names of all registered drivers.
+func registeredDrivers() []string {
+	driversMu.RLock()
+	defer driversMu.RUnlock()
+
+	names := make([]string, 0, len(drivers))
+	for name := range drivers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
 // getSQLDriverName maps Queen driver names to their corresponding SQL driver names.
 //
 // This function handles driver name aliases and returns the canonical SQL driver name
@@ -48,6 +187,12 @@ func getSQLDriverName(driverName string) string {
 		return SQLDriverSQLite
 	case DriverClickHouse:
 		return SQLDriverClickHouse
+	case DriverMSSQL, DriverSQLServer:
+		return SQLDriverMSSQL
+	case DriverCockroachDB, DriverCockroach:
+		return SQLDriverCockroachDB
+	case DriverYDB:
+		return SQLDriverYDB
 	default:
 		return driverName
 	}
@@ -55,20 +200,30 @@ func getSQLDriverName(driverName string) string {
 
 // createDriver creates the appropriate driver based on the driver name.
 func (app *App) createDriver(db *sql.DB) (queen.Driver, error) {
-	switch app.config.Driver {
-	case DriverPostgres, DriverPostgreSQL, "pgx":
-		return postgres.New(db), nil
-
-	case "mysql":
-		return mysql.New(db), nil
+	return app.createDriverInSchema(db, app.config.Schema)
+}
 
-	case "sqlite", "sqlite3":
-		return sqlite.New(db), nil
+// createDriverInSchema creates the configured driver with its tables in schema.
+func (app *App) createDriverInSchema(db *sql.DB, schema string) (queen.Driver, error) {
+	factory, ok := lookupDriver(app.config.Driver)
+	if !ok {
+		return nil, fmt.Errorf("unsupported driver: %s (supported: %s)",
+			app.config.Driver, strings.Join(registeredDrivers(), ", "))
+	}
 
-	case "clickhouse":
-		return clickhouse.New(db)
+	table := app.config.Table
+	if table == "" {
+		table = queen.DefaultConfig().TableName
+	}
 
-	default:
-		return nil, fmt.Errorf("unsupported driver: %s (supported: postgres, mysql, sqlite, clickhouse)", app.config.Driver)
+	if schema != "" {
+		schemaFactory, ok := lookupSchemaDriver(app.config.Driver)
+		if !ok {
+			return nil, fmt.Errorf("driver %s does not support --schema (supported: %s, %s, %s)",
+				app.config.Driver, DriverPostgres, DriverCockroachDB, DriverMSSQL)
+		}
+		return schemaFactory(db, table, schema)
 	}
+
+	return factory(db, table)
 }
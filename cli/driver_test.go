@@ -1,8 +1,13 @@
 package cli
 
 import (
+	"database/sql"
 	"strings"
 	"testing"
+
+	"github.com/honeynil/queen"
+	"github.com/honeynil/queen/drivers/mock"
+	"github.com/honeynil/queen/drivers/postgres"
 )
 
 func TestGetSQLDriverName(t *testing.T) {
@@ -16,6 +21,11 @@ func TestGetSQLDriverName(t *testing.T) {
 		{"sqlite", "sqlite3"},
 		{"sqlite3", "sqlite3"},
 		{"clickhouse", "clickhouse"},
+		{"mssql", "sqlserver"},
+		{"sqlserver", "sqlserver"},
+		{"cockroachdb", "pgx"},
+		{"cockroach", "pgx"},
+		{"ydb", "ydb"},
 		{"unknown", "unknown"}, // passthrough for unknown drivers
 	}
 
@@ -42,6 +52,11 @@ func TestCreateDriver(t *testing.T) {
 		{"sqlite", false, ""},
 		{"sqlite3", false, ""},
 		{"clickhouse", false, ""},
+		{"mssql", false, ""},
+		{"sqlserver", false, ""},
+		{"cockroachdb", false, ""},
+		{"cockroach", false, ""},
+		{"ydb", false, ""},
 		{"unknown", true, "unsupported driver"},
 		{"oracle", true, "unsupported driver"},
 	}
@@ -81,3 +96,50 @@ func TestCreateDriver(t *testing.T) {
 		})
 	}
 }
+
+func TestRegisterDriver(t *testing.T) {
+	var gotTable string
+	RegisterDriver("inhouse", func(db *sql.DB, tableName string) (queen.Driver, error) {
+		gotTable = tableName
+		return mock.New(), nil
+	})
+
+	app := &App{config: &Config{Driver: "InHouse", Table: "custom_migrations"}}
+	driver, err := app.createDriver(nil)
+	if err != nil {
+		t.Fatalf("createDriver() error = %v", err)
+	}
+	if _, ok := driver.(*mock.Driver); !ok {
+		t.Errorf("createDriver() = %T, want *mock.Driver", driver)
+	}
+	if gotTable != "custom_migrations" {
+		t.Errorf("factory got table %q, want %q", gotTable, "custom_migrations")
+	}
+
+	app.config.Driver = "unknown"
+	if _, err := app.createDriver(nil); err == nil || !strings.Contains(err.Error(), "inhouse") {
+		t.Errorf("unsupported driver error should list registered drivers, got %v", err)
+	}
+}
+
+func TestCreateDriver_Schema(t *testing.T) {
+	app := &App{config: &Config{Driver: "postgres", Table: "queen_migrations", Schema: "app"}}
+	driver, err := app.createDriver(nil)
+	if err != nil {
+		t.Fatalf("createDriver() error = %v", err)
+	}
+	pg, ok := driver.(*postgres.Driver)
+	if !ok {
+		t.Fatalf("createDriver() = %T, want *postgres.Driver", driver)
+	}
+	if pg.Schema != "app" {
+		t.Errorf("Schema = %q, want %q", pg.Schema, "app")
+	}
+
+	for _, name := range []string{"sqlite", "mysql"} {
+		app.config.Driver = name
+		if _, err := app.createDriver(nil); err == nil || !strings.Contains(err.Error(), "--schema") {
+			t.Errorf("createDriver(%s) error = %v, want --schema not supported", name, err)
+		}
+	}
+}
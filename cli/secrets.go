@@ -0,0 +1,130 @@
+package cli
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// envVarPattern matches ${VAR} and ${VAR:-default}.
+var envVarPattern = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)(:-([^}]*))?\}`)
+
+// expandEnv replaces ${VAR} and ${VAR:-default} with environment variables.
+// An unset variable without a default is an error, so a typo doesn't
+// silently produce an empty DSN. A bare $VAR is left as is, since it may be
+// part of a password.
+func expandEnv(s string) (string, error) {
+	var missing []string
+
+	expanded := envVarPattern.ReplaceAllStringFunc(s, func(match string) string {
+		groups := envVarPattern.FindStringSubmatch(match)
+		name, hasDefault, def := groups[1], groups[2] != "", groups[3]
+
+		if value, ok := os.LookupEnv(name); ok && (value != "" || !hasDefault) {
+			return value
+		}
+		if hasDefault {
+			return def
+		}
+		missing = append(missing, name)
+		return ""
+	})
+
+	if len(missing) > 0 {
+		return "", fmt.Errorf("environment variable %s is not set", strings.Join(missing, ", "))
+	}
+	return expanded, nil
+}
+
+// readSecretFile reads a secret mounted as a file (e.g. a Kubernetes secret),
+// without the trailing newline.
+func readSecretFile(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read secret file: %w", err)
+	}
+	return strings.TrimRight(string(data), "\r\n"), nil
+}
+
+// withPassword sets the password of dsn. It supports URL DSNs
+// (postgres://user@host/db), user@tcp(host)/db DSNs (MySQL) and
+// key=value DSNs (host=db user=app). A password already in the DSN,
+// including a password query parameter, is replaced.
+func withPassword(dsn, password string) (string, error) {
+	if strings.Contains(dsn, "://") {
+		u, err := url.Parse(dsn)
+		if err != nil {
+			return "", fmt.Errorf("failed to parse dsn: %w", err)
+		}
+		if query := u.Query(); query.Has("password") {
+			query.Set("password", password)
+			u.RawQuery = query.Encode()
+			return u.String(), nil
+		}
+		if u.User == nil {
+			return "", fmt.Errorf("dsn has no user to set the password for")
+		}
+		u.User = url.UserPassword(u.User.Username(), password)
+		return u.String(), nil
+	}
+
+	if user, rest, ok := strings.Cut(dsn, "@"); ok {
+		user, _, _ = strings.Cut(user, ":")
+		return user + ":" + password + "@" + rest, nil
+	}
+
+	if strings.Contains(dsn, "=") {
+		quoted := "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(password) + "'"
+		if keyValuePassword.MatchString(dsn) {
+			return keyValuePassword.ReplaceAllStringFunc(dsn, func(match string) string {
+				return keyValuePassword.FindStringSubmatch(match)[1] + quoted
+			}), nil
+		}
+		return dsn + " password=" + quoted, nil
+	}
+
+	return "", fmt.Errorf("cannot set password: unsupported dsn format")
+}
+
+// keyValuePassword matches the password of key=value DSNs.
+var keyValuePassword = regexp.MustCompile(`(?i)(\bpassword\s*=\s*)('(?:[^'\\]|\\.)*'|\S+)`)
+
+// secretKeys are the DSN keys and URL query parameters hidden by redactDSN.
+var secretKeys = []string{"password", "pwd", "sslpassword"}
+
+// keyValueSecret matches the secrets of key=value DSNs.
+var keyValueSecret = regexp.MustCompile(`(?i)(\b(?:` + strings.Join(secretKeys, "|") + `)\s*=\s*)('(?:[^'\\]|\\.)*'|\S+)`)
+
+// redactDSN hides the password of a DSN before it is printed.
+func redactDSN(dsn string) string {
+	if u, err := url.Parse(dsn); err == nil && strings.Contains(dsn, "://") {
+		redactQuery(u)
+		return u.Redacted()
+	}
+	// user:password@tcp(host)/db style DSNs
+	if i := strings.LastIndex(dsn, "@"); i >= 0 {
+		return "***@" + dsn[i+1:]
+	}
+	// host=db user=app password=secret style DSNs
+	return keyValueSecret.ReplaceAllString(dsn, "${1}xxxxx")
+}
+
+// redactQuery hides secret query parameters of a URL DSN, such as
+// sqlserver://user@host?password=secret.
+func redactQuery(u *url.URL) {
+	query := u.Query()
+	redacted := false
+	for key := range query {
+		for _, secret := range secretKeys {
+			if strings.EqualFold(key, secret) {
+				query[key] = []string{"xxxxx"}
+				redacted = true
+			}
+		}
+	}
+	if redacted {
+		u.RawQuery = query.Encode()
+	}
+}
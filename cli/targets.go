@@ -0,0 +1,203 @@
+package cli
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/honeynil/queen"
+	"github.com/olekukonko/tablewriter"
+)
+
+// multiTarget reports whether the command runs on --targets instead of a single database.
+func (app *App) multiTarget() bool {
+	return len(app.config.Targets) > 0
+}
+
+// setupMultiRunner creates a MultiRunner for --targets and returns it with
+// the number of targets.
+//
+// With --dsn, targets are schema names in that database. Without it, targets
+// are DSNs of separate databases (with --schema applied to each).
+func (app *App) setupMultiRunner() (*queen.MultiRunner, int, error) {
+	if err := app.loadConfig(); err != nil {
+		return nil, 0, err
+	}
+
+	if app.config.Driver == "" {
+		return nil, 0, fmt.Errorf("driver is required (use --driver or QUEEN_DRIVER)")
+	}
+
+	entries, err := expandTargets(app.config.Targets)
+	if err != nil {
+		return nil, 0, err
+	}
+	if len(entries) == 0 {
+		return nil, 0, fmt.Errorf("--targets is empty")
+	}
+
+	targets := make([]queen.Target, len(entries))
+	for i, entry := range entries {
+		dsn, schema, name := entry, app.config.Schema, redactDSN(entry)
+		if app.config.DSN != "" {
+			dsn, schema, name = app.config.DSN, entry, entry
+		}
+
+		targets[i] = queen.Target{
+			Name: name,
+			Open: func(ctx context.Context) (queen.Driver, error) {
+				return app.openDriver(ctx, dsn, schema)
+			},
+		}
+	}
+
+	runner := queen.NewMultiRunner(targets, app.registerFunc)
+	runner.Concurrency = app.config.Concurrency
+	runner.Config = app.queenConfig()
+
+	return runner, len(targets), nil
+}
+
+// expandTargets replaces @file entries with the non-empty, non-comment lines of the file.
+func expandTargets(entries []string) ([]string, error) {
+	var targets []string
+	for _, entry := range entries {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		path, ok := strings.CutPrefix(entry, "@")
+		if !ok {
+			targets = append(targets, entry)
+			continue
+		}
+
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read targets: %w", err)
+		}
+
+		scanner := bufio.NewScanner(f)
+		for scanner.Scan() {
+			line := strings.TrimSpace(scanner.Text())
+			if line != "" && !strings.HasPrefix(line, "#") {
+				targets = append(targets, line)
+			}
+		}
+		_ = f.Close()
+		if err := scanner.Err(); err != nil {
+			return nil, fmt.Errorf("failed to read targets from %s: %w", path, err)
+		}
+	}
+	return targets, nil
+}
+
+// outputTargets prints the per-target report and returns the aggregated error.
+func (app *App) outputTargets(results queen.MultiResult) error {
+	var err error
+	if app.config.JSON {
+		err = outputTargetsJSON(results)
+	} else {
+		err = outputTargetsTable(results)
+	}
+	if err != nil {
+		return err
+	}
+	return results.Err()
+}
+
+func outputTargetsTable(results queen.MultiResult) error {
+	table := tablewriter.NewWriter(os.Stdout)
+	table.Header([]string{"Target", "Result", "Current", "Applied", "Pending", "Duration", "Error"})
+
+	for _, r := range results {
+		result, errText := "ok", ""
+		if r.Err != nil {
+			result, errText = "FAILED", r.Err.Error()
+		}
+
+		current := r.Current
+		if current == "" {
+			current = "-"
+		}
+
+		if err := table.Append([]string{
+			r.Target,
+			result,
+			current,
+			fmt.Sprint(r.Applied),
+			fmt.Sprint(r.Pending),
+			r.Duration.Round(time.Millisecond).String(),
+			errText,
+		}); err != nil {
+			return err
+		}
+	}
+
+	if err := table.Render(); err != nil {
+		return err
+	}
+
+	failed := results.Failed()
+	fmt.Printf("\nSummary: %d targets, %d succeeded, %d failed\n", len(results), len(results)-len(failed), len(failed))
+	for _, r := range failed {
+		fmt.Printf("  ✗ %s: %v\n", r.Target, r.Err)
+	}
+	return nil
+}
+
+func outputTargetsJSON(results queen.MultiResult) error {
+	type targetJSON struct {
+		Target     string                  `json:"target"`
+		OK         bool                    `json:"ok"`
+		Current    string                  `json:"current"`
+		Applied    int                     `json:"applied"`
+		Pending    int                     `json:"pending"`
+		DurationMs int64                   `json:"duration_ms"`
+		Error      string                  `json:"error,omitempty"`
+		Migrations []queen.MigrationStatus `json:"migrations,omitempty"`
+	}
+
+	output := struct {
+		Targets []targetJSON `json:"targets"`
+		Summary struct {
+			Total     int      `json:"total"`
+			Succeeded int      `json:"succeeded"`
+			Failed    int      `json:"failed"`
+			FailedOn  []string `json:"failed_targets"`
+		} `json:"summary"`
+	}{
+		Targets: make([]targetJSON, len(results)),
+	}
+
+	output.Summary.FailedOn = []string{}
+	for i, r := range results {
+		t := targetJSON{
+			Target:     r.Target,
+			OK:         r.Err == nil,
+			Current:    r.Current,
+			Applied:    r.Applied,
+			Pending:    r.Pending,
+			DurationMs: r.Duration.Milliseconds(),
+			Migrations: r.Statuses,
+		}
+		if r.Err != nil {
+			t.Error = r.Err.Error()
+			output.Summary.FailedOn = append(output.Summary.FailedOn, r.Target)
+		}
+		output.Targets[i] = t
+	}
+
+	output.Summary.Total = len(results)
+	output.Summary.Failed = len(output.Summary.FailedOn)
+	output.Summary.Succeeded = output.Summary.Total - output.Summary.Failed
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(output)
+}
@@ -0,0 +1,83 @@
+package cli
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/honeynil/queen"
+	"github.com/honeynil/queen/drivers/mock"
+)
+
+func TestExpandTargets(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tenants.txt")
+	if err := os.WriteFile(path, []byte("# tenants\ntenant_b\n\n  tenant_c  \n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := expandTargets([]string{"tenant_a", "@" + path, " "})
+	if err != nil {
+		t.Fatalf("expandTargets() error = %v", err)
+	}
+	want := []string{"tenant_a", "tenant_b", "tenant_c"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expandTargets() = %v, want %v", got, want)
+	}
+
+	if _, err := expandTargets([]string{"@" + filepath.Join(t.TempDir(), "missing.txt")}); err == nil {
+		t.Error("expandTargets() with missing file should fail")
+	}
+}
+
+func TestSetupMultiRunner(t *testing.T) {
+	RegisterDriver("multimock", func(db *sql.DB, tableName string) (queen.Driver, error) {
+		_ = db.Close()
+		return mock.New(), nil
+	})
+
+	app := &App{
+		registerFunc: func(q *queen.Queen) {
+			q.MustAdd(queen.M{Version: "001", Name: "create_users", UpSQL: "CREATE TABLE users (id INTEGER)"})
+		},
+		dbOpener: func(dsn string) (*sql.DB, error) {
+			if dsn == "down" {
+				return nil, errors.New("connection refused")
+			}
+			db, _, err := sqlmock.New()
+			return db, err
+		},
+		config: &Config{Driver: "multimock", Table: DefaultTableName, Targets: []string{"db1", "down", "db2"}, Concurrency: 2},
+	}
+
+	runner, count, err := app.setupMultiRunner()
+	if err != nil {
+		t.Fatalf("setupMultiRunner() error = %v", err)
+	}
+	if count != 3 {
+		t.Errorf("count = %d, want 3", count)
+	}
+
+	results, err := runner.Up(context.Background())
+	if !errors.Is(err, queen.ErrTargetsFailed) {
+		t.Fatalf("Up() error = %v, want ErrTargetsFailed", err)
+	}
+	for i, want := range []struct {
+		target  string
+		applied int
+		failed  bool
+	}{
+		{"db1", 1, false},
+		{"down", 0, true},
+		{"db2", 1, false},
+	} {
+		r := results[i]
+		if r.Target != want.target || r.Applied != want.applied || (r.Err != nil) != want.failed {
+			t.Errorf("results[%d] = {%s applied=%d err=%v}, want %+v", i, r.Target, r.Applied, r.Err, want)
+		}
+	}
+}
@@ -0,0 +1,221 @@
+package cli
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"strings"
+	"text/template"
+)
+
+// migrationTemplateData is passed to migration templates.
+type migrationTemplateData struct {
+	Package     string // Go package, e.g. "migrations"
+	Version     string // e.g. "001"
+	Name        string // e.g. "create_users"
+	Description string // Name with spaces, e.g. "create users"
+	Variable    string // exported variable, e.g. "Migration001CreateUsers"
+	UpFunc      string // up function of Go migrations, e.g. "up001CreateUsers"
+	DownFunc    string // down function of Go migrations, e.g. "down001CreateUsers"
+}
+
+func newMigrationTemplateData(pkg, version, name string) migrationTemplateData {
+	return migrationTemplateData{
+		Package:     pkg,
+		Version:     version,
+		Name:        name,
+		Description: strings.ReplaceAll(name, "_", " "),
+		Variable:    migrationVariableName(version, name),
+		UpFunc:      fmt.Sprintf("up%s%s", versionIdentifier(version), toPascalCase(name)),
+		DownFunc:    fmt.Sprintf("down%s%s", versionIdentifier(version), toPascalCase(name)),
+	}
+}
+
+// sqlTemplate is the built-in template for --type sql.
+const sqlTemplate = `package {{.Package}}
+
+import "github.com/honeynil/queen"
+
+// {{.Variable}} {{.Description}}
+var {{.Variable}} = queen.M{
+	Version: "{{.Version}}",
+	Name:    "{{.Name}}",
+	UpSQL: ` + "`" + `
+		-- Write your migration here
+		-- Example: CREATE TABLE users (id INT PRIMARY KEY, email VARCHAR(255));
+	` + "`" + `,
+	DownSQL: ` + "`" + `
+		-- Write your rollback here
+		-- Example: DROP TABLE users;
+	` + "`" + `,
+}
+`
+
+// goTemplate is the built-in template for --type go.
+const goTemplate = `package {{.Package}}
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/honeynil/queen"
+)
+
+// {{.Variable}} {{.Description}}
+var {{.Variable}} = queen.M{
+	Version:        "{{.Version}}",
+	Name:           "{{.Name}}",
+	ManualChecksum: "v1", // Update this when you change the function
+	UpFunc:         {{.UpFunc}},
+	DownFunc:       {{.DownFunc}},
+}
+
+func {{.UpFunc}}(ctx context.Context, tx *sql.Tx) error {
+	// TODO: Implement your migration logic
+	// Example:
+	// rows, err := tx.QueryContext(ctx, "SELECT id, name FROM users")
+	// if err != nil {
+	//     return err
+	// }
+	// defer rows.Close()
+	//
+	// for rows.Next() {
+	//     var id int
+	//     var name string
+	//     if err := rows.Scan(&id, &name); err != nil {
+	//         return err
+	//     }
+	//     // Process data...
+	// }
+	//
+	// return rows.Err()
+	return nil
+}
+
+func {{.DownFunc}}(ctx context.Context, tx *sql.Tx) error {
+	// TODO: Implement your rollback logic
+	return nil
+}
+`
+
+// renderMigration renders the migration file from the template file at path,
+// or from the built-in template of migrationType if path is empty.
+func renderMigration(migrationType, path string, data migrationTemplateData) (string, error) {
+	text := sqlTemplate
+	if migrationType == "go" {
+		text = goTemplate
+	}
+
+	name := migrationType
+	if path != "" {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return "", fmt.Errorf("failed to read template: %w", err)
+		}
+		text, name = string(content), path
+	}
+
+	tmpl, err := template.New(name).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse template: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("failed to render template: %w", err)
+	}
+	return buf.String(), nil
+}
+
+// registerTemplate is used when register.go doesn't exist yet.
+const registerTemplate = `package %s
+
+import "github.com/honeynil/queen"
+
+// Register adds all migrations to q.
+func Register(q *queen.Queen) {
+}
+`
+
+// addToRegister appends q.MustAdd(<variable>) to the register function in
+// path, creating the file if it doesn't exist. The register function is
+// Register, or else the only function taking a *queen.Queen.
+func addToRegister(path string, data migrationTemplateData) error {
+	src, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		src, err = []byte(fmt.Sprintf(registerTemplate, data.Package)), nil
+	}
+	if err != nil {
+		return err
+	}
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, path, src, parser.ParseComments)
+	if err != nil {
+		return err
+	}
+
+	fn, param := findRegisterFunc(file)
+	if fn == nil {
+		return fmt.Errorf("no register function with a *queen.Queen parameter found")
+	}
+
+	// Insert the call on its own line, just before the closing brace
+	offset := fset.Position(fn.Body.Rbrace).Offset
+	call := fmt.Sprintf("\t%s.MustAdd(%s)\n", param, data.Variable)
+	if lineStart := bytes.LastIndexByte(src[:offset], '\n') + 1; len(bytes.TrimSpace(src[lineStart:offset])) == 0 {
+		offset = lineStart
+	} else {
+		call = "\n" + call
+	}
+
+	updated := make([]byte, 0, len(src)+len(call))
+	updated = append(updated, src[:offset]...)
+	updated = append(updated, call...)
+	updated = append(updated, src[offset:]...)
+
+	formatted, err := format.Source(updated)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, formatted, 0644)
+}
+
+// findRegisterFunc returns the register function of file and the name of its
+// *queen.Queen parameter.
+func findRegisterFunc(file *ast.File) (*ast.FuncDecl, string) {
+	var candidates []*ast.FuncDecl
+	params := make(map[*ast.FuncDecl]string)
+
+	for _, decl := range file.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Recv != nil || fn.Body == nil || fn.Type.Params.NumFields() != 1 {
+			continue
+		}
+
+		field := fn.Type.Params.List[0]
+		star, ok := field.Type.(*ast.StarExpr)
+		if !ok || len(field.Names) != 1 {
+			continue
+		}
+		sel, ok := star.X.(*ast.SelectorExpr)
+		if !ok || sel.Sel.Name != "Queen" {
+			continue
+		}
+
+		if fn.Name.Name == "Register" {
+			return fn, field.Names[0].Name
+		}
+		candidates = append(candidates, fn)
+		params[fn] = field.Names[0].Name
+	}
+
+	if len(candidates) == 1 {
+		return candidates[0], params[candidates[0]]
+	}
+	return nil, ""
+}
@@ -0,0 +1,103 @@
+package queen
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// DirtyMigration describes a migration whose execution started but never
+// finished, e.g. because the process was killed or a non-transactional
+// migration failed halfway.
+type DirtyMigration struct {
+	// Version is the version of the interrupted migration.
+	Version string
+
+	// Name is the name of the interrupted migration.
+	Name string
+
+	// Direction is "up" or "down".
+	Direction string
+
+	// StartedAt is when the migration started. Set by the driver.
+	StartedAt time.Time
+}
+
+// loadDirty caches dirty markers from the database, if the driver tracks them.
+func (q *Queen) loadDirty(ctx context.Context) error {
+	q.dirty = make(map[string]*DirtyMigration)
+
+	tracker, ok := q.driver.(DirtyTracker)
+	if !ok {
+		return nil
+	}
+
+	dirty, err := tracker.GetDirty(ctx)
+	if err != nil {
+		return err
+	}
+	for i := range dirty {
+		q.dirty[dirty[i].Version] = &dirty[i]
+	}
+
+	return nil
+}
+
+// checkDirty returns ErrDirty if any migration was interrupted.
+// Requires loadApplied to be called first.
+func (q *Queen) checkDirty() error {
+	if len(q.dirty) == 0 {
+		return nil
+	}
+
+	dirty := make([]*DirtyMigration, 0, len(q.dirty))
+	for _, d := range q.dirty {
+		dirty = append(dirty, d)
+	}
+	sort.Slice(dirty, func(i, j int) bool {
+		return compareVersions(dirty[i].Version, dirty[j].Version) < 0
+	})
+
+	migrations := make([]string, len(dirty))
+	for i, d := range dirty {
+		migrations[i] = fmt.Sprintf("%s (%s)", d.Version, d.Direction)
+	}
+
+	return fmt.Errorf("%w: interrupted migration %s; verify the schema, then resolve with MarkApplied or MarkPending (migrate force/unmark)",
+		ErrDirty, strings.Join(migrations, ", "))
+}
+
+// markDirty records that m is about to be executed in the given direction.
+func (q *Queen) markDirty(ctx context.Context, m *Migration, direction string) error {
+	tracker, ok := q.driver.(DirtyTracker)
+	if !ok {
+		return nil
+	}
+
+	if err := tracker.SetDirty(ctx, m, direction); err != nil {
+		return fmt.Errorf("failed to set dirty marker: %w", err)
+	}
+	q.dirty[m.Version] = &DirtyMigration{Version: m.Version, Name: m.Name, Direction: direction, StartedAt: time.Now()}
+
+	return nil
+}
+
+// clearDirty removes the dirty marker of version, if there is one.
+func (q *Queen) clearDirty(ctx context.Context, version string) error {
+	tracker, ok := q.driver.(DirtyTracker)
+	if !ok {
+		return nil
+	}
+	if _, dirty := q.dirty[version]; !dirty {
+		return nil
+	}
+
+	if err := tracker.ClearDirty(ctx, version); err != nil {
+		return fmt.Errorf("failed to clear dirty marker: %w", err)
+	}
+	delete(q.dirty, version)
+
+	return nil
+}
@@ -0,0 +1,146 @@
+package queen
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// dirtyDriver is a memDriver that tracks dirty markers.
+type dirtyDriver struct {
+	*memDriver
+	dirty map[string]DirtyMigration
+}
+
+func newDirtyDriver() *dirtyDriver {
+	return &dirtyDriver{memDriver: newMemDriver(), dirty: make(map[string]DirtyMigration)}
+}
+
+func (d *dirtyDriver) SetDirty(ctx context.Context, m *Migration, direction string) error {
+	d.dirty[m.Version] = DirtyMigration{Version: m.Version, Name: m.Name, Direction: direction, StartedAt: time.Now()}
+	return nil
+}
+
+func (d *dirtyDriver) ClearDirty(ctx context.Context, version string) error {
+	delete(d.dirty, version)
+	return nil
+}
+
+func (d *dirtyDriver) GetDirty(ctx context.Context) ([]DirtyMigration, error) {
+	dirty := make([]DirtyMigration, 0, len(d.dirty))
+	for _, m := range d.dirty {
+		dirty = append(dirty, m)
+	}
+	return dirty, nil
+}
+
+func failingMigration(version, name string) M {
+	m := noopMigration(version, name)
+	m.UpFunc = func(ctx context.Context, tx *sql.Tx) error { return errors.New("boom") }
+	return m
+}
+
+func TestDirtyState(t *testing.T) {
+	t.Parallel()
+
+	t.Run("successful migrations leave no marker", func(t *testing.T) {
+		t.Parallel()
+
+		driver := newDirtyDriver()
+		q := New(driver)
+		q.MustAdd(noopMigration("001", "first"))
+		q.MustAdd(noopMigration("002", "second"))
+
+		ctx := context.Background()
+		if err := q.Up(ctx); err != nil {
+			t.Fatalf("Up() error = %v", err)
+		}
+		if err := q.Down(ctx, 1); err != nil {
+			t.Fatalf("Down() error = %v", err)
+		}
+		if len(driver.dirty) != 0 {
+			t.Errorf("dirty = %v, want none", driver.dirty)
+		}
+	})
+
+	t.Run("failed migration blocks further runs", func(t *testing.T) {
+		t.Parallel()
+
+		driver := newDirtyDriver()
+		q := New(driver)
+		q.MustAdd(noopMigration("001", "first"))
+		q.MustAdd(failingMigration("002", "second"))
+
+		ctx := context.Background()
+		if err := q.Up(ctx); err == nil {
+			t.Fatal("Up() expected error")
+		}
+		if d, ok := driver.dirty["002"]; !ok || d.Direction != "up" {
+			t.Fatalf("dirty = %v, want 002 (up)", driver.dirty)
+		}
+
+		for name, run := range map[string]func() error{
+			"Up":        func() error { return q.Up(ctx) },
+			"Down":      func() error { return q.Down(ctx, 1) },
+			"Reset":     func() error { return q.Reset(ctx) },
+			"MigrateTo": func() error { return q.MigrateTo(ctx, "001") },
+		} {
+			if err := run(); !errors.Is(err, ErrDirty) {
+				t.Errorf("%s() error = %v, want ErrDirty", name, err)
+			}
+		}
+
+		statuses, err := q.Status(ctx)
+		if err != nil {
+			t.Fatalf("Status() error = %v", err)
+		}
+		if statuses[1].Status != StatusDirty {
+			t.Errorf("Status(002) = %s, want %s", statuses[1].Status, StatusDirty)
+		}
+	})
+
+	t.Run("MarkPending resolves", func(t *testing.T) {
+		t.Parallel()
+
+		driver := newDirtyDriver()
+		q := New(driver)
+		q.MustAdd(failingMigration("001", "first"))
+
+		ctx := context.Background()
+		_ = q.Up(ctx)
+
+		if err := q.MarkPending(ctx, "001"); err != nil {
+			t.Fatalf("MarkPending() error = %v", err)
+		}
+		if len(driver.dirty) != 0 {
+			t.Errorf("dirty = %v, want none", driver.dirty)
+		}
+		if got := driver.appliedVersions(); len(got) != 0 {
+			t.Errorf("applied = %v, want none", got)
+		}
+	})
+
+	t.Run("MarkApplied resolves", func(t *testing.T) {
+		t.Parallel()
+
+		driver := newDirtyDriver()
+		q := New(driver)
+		q.MustAdd(failingMigration("001", "first"))
+
+		ctx := context.Background()
+		_ = q.Up(ctx)
+
+		if err := q.MarkApplied(ctx, "001"); err != nil {
+			t.Fatalf("MarkApplied() error = %v", err)
+		}
+		if len(driver.dirty) != 0 {
+			t.Errorf("dirty = %v, want none", driver.dirty)
+		}
+		if got := driver.appliedVersions(); !reflect.DeepEqual(got, []string{"001"}) {
+			t.Errorf("applied = %v, want [001]", got)
+		}
+	})
+}
@@ -80,6 +80,100 @@ type Driver interface {
 	Close() error
 }
 
+// TxRecorder is an optional interface for drivers whose database supports
+// transactional DDL.
+//
+// When a driver implements TxRecorder and SupportsTransactionalDDL reports
+// true, Queen records (or removes) the migration tracking row on the same
+// *sql.Tx that executed the migration. Schema changes and their bookkeeping
+// are then committed atomically, so a crash between the two can no longer
+// leave a migration applied but unrecorded.
+//
+// Drivers for engines that auto-commit DDL (MySQL, ClickHouse, YDB) report
+// false and Queen falls back to calling Record/Remove after Exec commits.
+type TxRecorder interface {
+	// SupportsTransactionalDDL reports whether DDL statements participate
+	// in transactions and can be rolled back together with the tracking row.
+	SupportsTransactionalDDL() bool
+
+	// RecordTx marks a migration as applied using the given transaction.
+	RecordTx(ctx context.Context, tx *sql.Tx, m *Migration) error
+
+	// RemoveTx removes a migration record using the given transaction.
+	RemoveTx(ctx context.Context, tx *sql.Tx, version string) error
+}
+
+// NoTxExecutor is an optional interface for drivers that can run migrations
+// outside of a transaction.
+//
+// Queen uses it for migrations with NoTransaction set, e.g. PostgreSQL
+// CREATE INDEX CONCURRENTLY or SQLite VACUUM, which fail inside BeginTx.
+type NoTxExecutor interface {
+	// ExecNoTx executes a function on a dedicated connection without a transaction.
+	// The connection must be returned to the pool after fn returns.
+	ExecNoTx(ctx context.Context, fn func(*sql.Conn) error) error
+}
+
+// StatementSplitter is an optional interface for drivers whose database
+// rejects several statements in a single Exec call (MySQL without
+// multiStatements, ClickHouse, YDB) or uses batch separators (MS SQL "GO").
+//
+// When the driver implements it, Queen splits UpSQL/DownSQL and executes the
+// statements one by one. A failing statement is reported in MigrationError
+// with its index and text. Go function migrations are not affected.
+//
+// Drivers built on drivers/base can implement it with base.SplitStatements.
+type StatementSplitter interface {
+	// SplitStatements splits a SQL script into individual statements,
+	// respecting the dialect's quoting, comment and block rules.
+	SplitStatements(query string) ([]string, error)
+}
+
+// Auditor is an optional interface for drivers that keep an append-only
+// audit log of migration operations.
+//
+// Driver.Remove deletes the tracking row on rollback, so the tracking table
+// alone cannot tell that a migration was ever applied and reverted. When the
+// driver implements Auditor, Queen appends an AuditEntry for every up, down,
+// baseline and repair (force, unmark, rehash) operation, successful or not.
+// The audit table is created in Init.
+type Auditor interface {
+	// RecordAudit appends an entry to the audit log.
+	RecordAudit(ctx context.Context, e AuditEntry) error
+
+	// GetAudit returns all audit entries sorted by execution time in ascending order.
+	GetAudit(ctx context.Context) ([]AuditEntry, error)
+}
+
+// DirtyTracker is an optional interface for drivers that record migrations
+// whose execution started but never finished.
+//
+// Queen sets the marker before executing a migration that is not atomic with
+// its tracking row (NoTransaction migrations and drivers without transactional
+// DDL) and clears it after Record or Remove. A marker left behind by a killed
+// process or a failed migration makes Up and Down return ErrDirty until an
+// operator resolves it with Queen.MarkApplied or Queen.MarkPending.
+type DirtyTracker interface {
+	// SetDirty records that m is being executed in the given direction ("up" or "down").
+	SetDirty(ctx context.Context, m *Migration, direction string) error
+
+	// ClearDirty removes the marker of the given version.
+	ClearDirty(ctx context.Context, version string) error
+
+	// GetDirty returns all dirty markers.
+	GetDirty(ctx context.Context) ([]DirtyMigration, error)
+}
+
+// ChecksumUpdater is an optional interface for drivers that can update the
+// stored checksum of an applied migration in place.
+//
+// Queen.UpdateChecksum uses it to keep applied_at and history columns intact.
+// Without it, the tracking row is removed and recorded again.
+type ChecksumUpdater interface {
+	// UpdateChecksum replaces the stored checksum of the given version.
+	UpdateChecksum(ctx context.Context, version, checksum string) error
+}
+
 // Applied represents a migration that has been applied to the database.
 // This is returned by Driver.GetApplied().
 type Applied struct {
@@ -94,4 +188,54 @@ type Applied struct {
 
 	// Checksum is the hash of the migration content at the time it was applied.
 	Checksum string
+
+	// Duration is how long the migration took to execute.
+	// Zero for migrations applied before history columns were added.
+	Duration time.Duration
+
+	// AppliedBy identifies who applied the migration (OS user or Config.AppliedBy).
+	AppliedBy string
+
+	// Hostname is the host that applied the migration.
+	Hostname string
+
+	// QueenVersion is the version of Queen that applied the migration.
+	QueenVersion string
+
+	// BuildTag is the application build tag configured via Config.BuildTag.
+	BuildTag string
+}
+
+// LeaseLocker is an optional interface for drivers whose lock is a lease that
+// expires unless it is renewed (table-based locks in ClickHouse, CockroachDB
+// and YDB). Such drivers renew the lease in the background between Lock and
+// Unlock.
+//
+// If renewal fails, another process may take the lock while migrations are
+// still running. Queen watches LockLost and cancels the context of the run;
+// context.Cause of that context wraps ErrLockLost.
+type LeaseLocker interface {
+	// LockLost returns a channel that is closed when the lease of the
+	// current lock could not be renewed, or nil if no lock is held.
+	LockLost() <-chan struct{}
+
+	// LockErr returns why the lease was lost.
+	LockErr() error
+}
+
+// LockInspector is an optional interface for drivers that can report and
+// forcibly release the migration lock held by another process.
+//
+// Operators use it when a deploy died while holding the lock: table-based
+// locks stay until they expire, and session locks (PostgreSQL advisory locks,
+// MySQL named locks, SQL Server application locks) stay until the holder's
+// connection is closed.
+type LockInspector interface {
+	// LockInfo returns the current holder of the migration lock,
+	// or nil if the lock is free.
+	LockInfo(ctx context.Context) (*LockInfo, error)
+
+	// ForceUnlock releases the migration lock regardless of its owner.
+	// Session locks are released by terminating the holder's session.
+	ForceUnlock(ctx context.Context) error
 }
@@ -0,0 +1,132 @@
+package base
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/honeynil/queen"
+)
+
+// Outcome values stored in the audit table.
+const (
+	OutcomeSuccess = "success"
+	OutcomeFailure = "failure"
+)
+
+// AuditTableName returns the name of the audit table: the migrations table name with an "_audit" suffix.
+//
+// The audit table is an append-only log with one row per up, down, baseline
+// and repair (force, unmark, rehash) operation, including failed ones, with
+// its outcome, duration and operator (see queen.Auditor).
+//
+// Drivers create the audit table in Init with the columns version, name, direction,
+// outcome, error_message, duration_ms, applied_by, hostname, queen_version, build_tag
+// and executed_at (defaulting to the current time).
+func (d *Driver) AuditTableName() string {
+	return d.TableName + "_audit"
+}
+
+// RecordAudit appends an entry to the audit table.
+//
+// executed_at is filled by the column default.
+// Implements queen.Auditor together with GetAudit.
+func (d *Driver) RecordAudit(ctx context.Context, e queen.AuditEntry) error {
+	columns := []string{
+		"version", "name", "direction", "outcome", "error_message",
+		"duration_ms", "applied_by", "hostname", "queen_version", "build_tag",
+	}
+
+	placeholders := make([]string, len(columns))
+	for i := range columns {
+		placeholders[i] = d.Config.Placeholder(i + 1)
+	}
+
+	query := fmt.Sprintf(`
+		INSERT INTO %s (%s)
+		VALUES (%s)
+	`,
+		d.QuoteTable(d.AuditTableName()),
+		strings.Join(columns, ", "),
+		strings.Join(placeholders, ", "),
+	)
+
+	_, err := d.DB.ExecContext(ctx, query, AuditArgs(e)...)
+	return err
+}
+
+// AuditArgs returns the values of an audit entry in the column order used by
+// RecordAudit, without executed_at.
+func AuditArgs(e queen.AuditEntry) []any {
+	outcome := OutcomeSuccess
+	if !e.Success {
+		outcome = OutcomeFailure
+	}
+
+	return []any{
+		e.Version,
+		e.Name,
+		e.Direction,
+		outcome,
+		e.Error,
+		e.Duration.Milliseconds(),
+		e.AppliedBy,
+		e.Hostname,
+		e.QueenVersion,
+		e.BuildTag,
+	}
+}
+
+// GetAudit returns all audit entries sorted by executed_at in ascending order.
+//
+// Uses the optional ParseTime strategy for SQLite compatibility.
+func (d *Driver) GetAudit(ctx context.Context) ([]queen.AuditEntry, error) {
+	query := fmt.Sprintf(`
+		SELECT version, name, direction, outcome, error_message,
+			duration_ms, applied_by, hostname, queen_version, build_tag, executed_at
+		FROM %s
+		ORDER BY executed_at ASC
+	`, d.QuoteTable(d.AuditTableName()))
+
+	rows, err := d.DB.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	var entries []queen.AuditEntry
+	for rows.Next() {
+		var e queen.AuditEntry
+		var outcome string
+		var durationMs int64
+		var executedAtStr string
+
+		dest := []any{
+			&e.Version, &e.Name, &e.Direction, &outcome, &e.Error,
+			&durationMs, &e.AppliedBy, &e.Hostname, &e.QueenVersion, &e.BuildTag, &e.ExecutedAt,
+		}
+		// If custom time parser is provided (for SQLite)
+		if d.Config.ParseTime != nil {
+			dest[len(dest)-1] = &executedAtStr
+		}
+
+		if err := rows.Scan(dest...); err != nil {
+			return nil, err
+		}
+
+		if d.Config.ParseTime != nil {
+			parsedTime, err := d.Config.ParseTime(executedAtStr)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse executed_at: %w", err)
+			}
+			e.ExecutedAt = parsedTime
+		}
+		e.Success = outcome == OutcomeSuccess
+		e.Duration = time.Duration(durationMs) * time.Millisecond
+
+		entries = append(entries, e)
+	}
+
+	return entries, rows.Err()
+}
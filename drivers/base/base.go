@@ -4,9 +4,11 @@
 // database drivers (PostgreSQL, MySQL, SQLite, ClickHouse, CockroachDB).
 //
 // The base package provides:
-//   - Transaction management (Exec)
+//   - Transaction management (Exec, ExecNoTx)
 //   - Connection lifecycle (Close)
 //   - Common migration operations (GetApplied, Record, Remove)
+//   - Transactional recording for databases with transactional DDL (RecordTx, RemoveTx)
+//   - Optional history columns and their schema upgrade (UpgradeSchema)
 //   - SQL identifier quoting strategies
 //   - Placeholder formatting strategies
 //
@@ -21,6 +23,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/honeynil/queen"
@@ -43,6 +46,32 @@ type Config struct {
 	// Most drivers: nil (use standard scanning)
 	// SQLite: parses from ISO8601 string
 	ParseTime func(src interface{}) (time.Time, error)
+
+	// TransactionalDDL reports whether the database supports transactional DDL.
+	// When true, Queen records migrations inside the migration transaction
+	// using RecordTx/RemoveTx.
+	// PostgreSQL/CockroachDB/SQLite/MS SQL Server: true
+	// MySQL/ClickHouse/YDB: false (DDL causes an implicit commit)
+	TransactionalDDL bool
+
+	// HistoryTypes enables the optional history columns (duration_ms, applied_by,
+	// hostname, queen_version, build_tag) with the given column types.
+	// Zero value: only version, name, applied_at and checksum are stored.
+	HistoryTypes HistoryTypes
+
+	// AddColumn builds an ALTER TABLE statement adding a column (optional).
+	// Arguments are already quoted.
+	// Default: ALTER TABLE <table> ADD COLUMN <column> <type>
+	// MS SQL Server: ALTER TABLE <table> ADD <column> <type>
+	AddColumn func(table, column, columnType string) string
+
+	// SearchPath builds the statement that makes Driver.Schema the default for
+	// unqualified names in migrations (optional). schema is already quoted,
+	// local scopes the setting to the migration transaction, and an empty
+	// schema restores the default.
+	// PostgreSQL/CockroachDB: SearchPath
+	// MS SQL Server: nil (the default schema is a property of the database user)
+	SearchPath func(schema string, local bool) string
 }
 
 // Driver provides a base implementation of common queen.Driver methods.
@@ -61,6 +90,10 @@ type Driver struct {
 	DB        *sql.DB
 	TableName string
 	Config    Config
+
+	// Schema qualifies the migrations table and its companion tables
+	// (optional). Empty means the connection's default schema.
+	Schema string
 }
 
 // Exec executes a function within a transaction with the specified isolation level.
@@ -76,7 +109,8 @@ type Driver struct {
 //   - sql.LevelSerializable: full isolation
 //
 // This provides ACID guarantees for migration execution.
-// Identical implementation for all database drivers.
+// When Schema and SearchPath are set, the schema is selected for the
+// duration of the transaction.
 func (d *Driver) Exec(ctx context.Context, isolationLevel sql.IsolationLevel, fn func(*sql.Tx) error) error {
 	txOpts := &sql.TxOptions{
 		Isolation: isolationLevel,
@@ -87,6 +121,13 @@ func (d *Driver) Exec(ctx context.Context, isolationLevel sql.IsolationLevel, fn
 		return err
 	}
 
+	if query := d.searchPathQuery(true); query != "" {
+		if _, err := tx.ExecContext(ctx, query); err != nil {
+			_ = tx.Rollback()
+			return fmt.Errorf("failed to select schema '%s': %w", d.Schema, err)
+		}
+	}
+
 	if err := fn(tx); err != nil {
 		// Ignore rollback error, return original error
 		_ = tx.Rollback()
@@ -96,6 +137,31 @@ func (d *Driver) Exec(ctx context.Context, isolationLevel sql.IsolationLevel, fn
 	return tx.Commit()
 }
 
+// ExecNoTx executes a function on a dedicated connection without a transaction.
+//
+// Used for migrations with NoTransaction set, such as PostgreSQL
+// CREATE INDEX CONCURRENTLY, which cannot run inside a transaction block.
+// The connection is returned to the pool after fn returns, with the
+// schema selected via SearchPath reset first.
+func (d *Driver) ExecNoTx(ctx context.Context, fn func(*sql.Conn) error) error {
+	conn, err := d.DB.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = conn.Close() }()
+
+	if query := d.searchPathQuery(false); query != "" {
+		if _, err := conn.ExecContext(ctx, query); err != nil {
+			return fmt.Errorf("failed to select schema '%s': %w", d.Schema, err)
+		}
+		defer func() {
+			_, _ = conn.ExecContext(context.WithoutCancel(ctx), d.Config.SearchPath("", false))
+		}()
+	}
+
+	return fn(conn)
+}
+
 // Close closes the database connection.
 //
 // Identical implementation for all database drivers.
@@ -107,12 +173,18 @@ func (d *Driver) Close() error {
 //
 // Uses the QuoteIdentifier strategy for SQL identifier escaping.
 // Uses the optional ParseTime strategy for SQLite compatibility.
+// History columns are read when HistoryTypes is configured.
 func (d *Driver) GetApplied(ctx context.Context) ([]queen.Applied, error) {
+	columns := "version, name, applied_at, checksum"
+	for _, c := range d.HistoryColumns() {
+		columns += ", " + c.Name
+	}
+
 	query := fmt.Sprintf(`
-		SELECT version, name, applied_at, checksum
+		SELECT %s
 		FROM %s
 		ORDER BY applied_at ASC
-	`, d.Config.QuoteIdentifier(d.TableName))
+	`, columns, d.QuoteTable(d.TableName))
 
 	rows, err := d.DB.QueryContext(ctx, query)
 	if err != nil {
@@ -123,24 +195,30 @@ func (d *Driver) GetApplied(ctx context.Context) ([]queen.Applied, error) {
 	var applied []queen.Applied
 	for rows.Next() {
 		var a queen.Applied
+		var appliedAtStr string
+		var history historyScan
 
+		dest := []any{&a.Version, &a.Name, &a.AppliedAt, &a.Checksum}
 		// If custom time parser is provided (for SQLite)
 		if d.Config.ParseTime != nil {
-			var appliedAtStr string
-			if err := rows.Scan(&a.Version, &a.Name, &appliedAtStr, &a.Checksum); err != nil {
-				return nil, err
-			}
+			dest[2] = &appliedAtStr
+		}
+		if d.hasHistory() {
+			dest = append(dest, history.dest()...)
+		}
+
+		if err := rows.Scan(dest...); err != nil {
+			return nil, err
+		}
+
+		if d.Config.ParseTime != nil {
 			parsedTime, err := d.Config.ParseTime(appliedAtStr)
 			if err != nil {
 				return nil, fmt.Errorf("failed to parse applied_at: %w", err)
 			}
 			a.AppliedAt = parsedTime
-		} else {
-			// Standard scanning for other databases
-			if err := rows.Scan(&a.Version, &a.Name, &a.AppliedAt, &a.Checksum); err != nil {
-				return nil, err
-			}
 		}
+		history.apply(&a)
 
 		applied = append(applied, a)
 	}
@@ -152,18 +230,10 @@ func (d *Driver) GetApplied(ctx context.Context) ([]queen.Applied, error) {
 //
 // Uses Placeholder and QuoteIdentifier strategies to generate
 // database-specific SQL queries.
+//
+// History columns are filled from the queen.RecordInfo attached to ctx.
 func (d *Driver) Record(ctx context.Context, m *queen.Migration) error {
-	query := fmt.Sprintf(`
-		INSERT INTO %s (version, name, checksum)
-		VALUES (%s, %s, %s)
-	`,
-		d.Config.QuoteIdentifier(d.TableName),
-		d.Config.Placeholder(1),
-		d.Config.Placeholder(2),
-		d.Config.Placeholder(3),
-	)
-
-	_, err := d.DB.ExecContext(ctx, query, m.Version, m.Name, m.Checksum())
+	_, err := d.DB.ExecContext(ctx, d.recordQuery(), d.recordArgs(ctx, m)...)
 	return err
 }
 
@@ -172,17 +242,93 @@ func (d *Driver) Record(ctx context.Context, m *queen.Migration) error {
 // Uses Placeholder and QuoteIdentifier strategies to generate
 // database-specific SQL queries.
 func (d *Driver) Remove(ctx context.Context, version string) error {
+	_, err := d.DB.ExecContext(ctx, d.removeQuery(), version)
+	return err
+}
+
+// UpdateChecksum replaces the stored checksum of an applied migration,
+// keeping applied_at and history columns intact.
+//
+// Implements queen.ChecksumUpdater.
+func (d *Driver) UpdateChecksum(ctx context.Context, version, checksum string) error {
 	query := fmt.Sprintf(`
-		DELETE FROM %s WHERE version = %s
+		UPDATE %s
+		SET checksum = %s
+		WHERE version = %s
 	`,
-		d.Config.QuoteIdentifier(d.TableName),
+		d.QuoteTable(d.TableName),
 		d.Config.Placeholder(1),
+		d.Config.Placeholder(2),
 	)
 
-	_, err := d.DB.ExecContext(ctx, query, version)
+	_, err := d.DB.ExecContext(ctx, query, checksum, version)
+	return err
+}
+
+// SupportsTransactionalDDL reports the TransactionalDDL capability from Config.
+//
+// Together with RecordTx and RemoveTx this implements queen.TxRecorder.
+func (d *Driver) SupportsTransactionalDDL() bool {
+	return d.Config.TransactionalDDL
+}
+
+// RecordTx marks a migration as applied using the migration transaction.
+//
+// The tracking row is committed or rolled back together with the migration.
+func (d *Driver) RecordTx(ctx context.Context, tx *sql.Tx, m *queen.Migration) error {
+	_, err := tx.ExecContext(ctx, d.recordQuery(), d.recordArgs(ctx, m)...)
+	return err
+}
+
+// RemoveTx removes a migration record using the rollback transaction.
+//
+// The tracking row is removed only if the rollback itself commits.
+func (d *Driver) RemoveTx(ctx context.Context, tx *sql.Tx, version string) error {
+	_, err := tx.ExecContext(ctx, d.removeQuery(), version)
 	return err
 }
 
+// recordQuery builds the INSERT statement used by Record and RecordTx.
+func (d *Driver) recordQuery() string {
+	columns := []string{"version", "name", "checksum"}
+	for _, c := range d.HistoryColumns() {
+		columns = append(columns, c.Name)
+	}
+
+	placeholders := make([]string, len(columns))
+	for i := range columns {
+		placeholders[i] = d.Config.Placeholder(i + 1)
+	}
+
+	return fmt.Sprintf(`
+		INSERT INTO %s (%s)
+		VALUES (%s)
+	`,
+		d.QuoteTable(d.TableName),
+		strings.Join(columns, ", "),
+		strings.Join(placeholders, ", "),
+	)
+}
+
+// recordArgs returns the arguments of recordQuery.
+func (d *Driver) recordArgs(ctx context.Context, m *queen.Migration) []any {
+	args := []any{m.Version, m.Name, m.Checksum()}
+	if d.hasHistory() {
+		args = append(args, RecordArgs(ctx)...)
+	}
+	return args
+}
+
+// removeQuery builds the DELETE statement used by Remove and RemoveTx.
+func (d *Driver) removeQuery() string {
+	return fmt.Sprintf(`
+		DELETE FROM %s WHERE version = %s
+	`,
+		d.QuoteTable(d.TableName),
+		d.Config.Placeholder(1),
+	)
+}
+
 // --- Placeholder Strategies ---
 
 // PlaceholderDollar creates placeholders in the format $1, $2, $3...
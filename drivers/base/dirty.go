@@ -0,0 +1,102 @@
+package base
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/honeynil/queen"
+)
+
+// DirtyTableName returns the name of the dirty marker table: the migrations
+// table name with a "_dirty" suffix.
+//
+// Drivers create the table in Init with the columns version, name, direction
+// and started_at (defaulting to the current time).
+func (d *Driver) DirtyTableName() string {
+	return d.TableName + "_dirty"
+}
+
+// SetDirty records that a migration is being executed in the given direction.
+//
+// Implements queen.DirtyTracker together with ClearDirty and GetDirty.
+func (d *Driver) SetDirty(ctx context.Context, m *queen.Migration, direction string) error {
+	query := fmt.Sprintf(`
+		INSERT INTO %s (version, name, direction)
+		VALUES (%s, %s, %s)
+	`,
+		d.QuoteTable(d.DirtyTableName()),
+		d.Config.Placeholder(1),
+		d.Config.Placeholder(2),
+		d.Config.Placeholder(3),
+	)
+
+	_, err := d.DB.ExecContext(ctx, query, m.Version, m.Name, direction)
+	return err
+}
+
+// ClearDirty removes the dirty marker of a migration.
+func (d *Driver) ClearDirty(ctx context.Context, version string) error {
+	query := fmt.Sprintf(`
+		DELETE FROM %s
+		WHERE version = %s
+	`,
+		d.QuoteTable(d.DirtyTableName()),
+		d.Config.Placeholder(1),
+	)
+
+	_, err := d.DB.ExecContext(ctx, query, version)
+	return err
+}
+
+// GetDirty returns all dirty markers sorted by started_at in ascending order.
+//
+// Uses the optional ParseTime strategy for SQLite compatibility.
+func (d *Driver) GetDirty(ctx context.Context) ([]queen.DirtyMigration, error) {
+	query := fmt.Sprintf(`
+		SELECT version, name, direction, started_at
+		FROM %s
+		ORDER BY started_at ASC
+	`, d.QuoteTable(d.DirtyTableName()))
+
+	return d.QueryDirty(ctx, query)
+}
+
+// QueryDirty runs query and scans the dirty markers it returns, for drivers
+// that need their own GetDirty query.
+//
+// The query must select version, name, direction and started_at.
+func (d *Driver) QueryDirty(ctx context.Context, query string) ([]queen.DirtyMigration, error) {
+	rows, err := d.DB.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	var dirty []queen.DirtyMigration
+	for rows.Next() {
+		var m queen.DirtyMigration
+		var startedAtStr string
+
+		dest := []any{&m.Version, &m.Name, &m.Direction, &m.StartedAt}
+		// If custom time parser is provided (for SQLite)
+		if d.Config.ParseTime != nil {
+			dest[3] = &startedAtStr
+		}
+
+		if err := rows.Scan(dest...); err != nil {
+			return nil, err
+		}
+
+		if d.Config.ParseTime != nil {
+			parsedTime, err := d.Config.ParseTime(startedAtStr)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse started_at: %w", err)
+			}
+			m.StartedAt = parsedTime
+		}
+
+		dirty = append(dirty, m)
+	}
+
+	return dirty, rows.Err()
+}
@@ -0,0 +1,148 @@
+package base
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// errLockNotHeld is reported when the lock row no longer belongs to this process.
+var errLockNotHeld = errors.New("lock is held by another process")
+
+// Heartbeat renews a table lock in the background while it is held.
+//
+// AcquireTableLock inserts a lock row that expires after the lock timeout.
+// Migrations that run longer than that (e.g. ClickHouse or YDB backfills)
+// would let a second process take the lock, so drivers start a Heartbeat
+// after acquiring the lock and stop it in Unlock.
+//
+// Every lease/3 the heartbeat runs RenewQuery to push expires_at to
+// now + lease, then HeldQuery to verify the lock still belongs to this
+// process. A failed renewal is retried on the next tick while the previous
+// lease is still valid. When the lock is taken by another process or the
+// lease runs out, Lost is closed and Err reports why.
+//
+// All methods are safe to call on a nil *Heartbeat.
+type Heartbeat struct {
+	cancel context.CancelFunc
+	done   chan struct{}
+	lost   chan struct{}
+
+	mu  sync.Mutex
+	err error
+}
+
+// StartHeartbeat starts renewing the lock identified by lockKey and ownerID.
+//
+// Returns nil if config has no RenewQuery or lease is not positive.
+func StartHeartbeat(db *sql.DB, config TableLockConfig, lockKey, ownerID string, lease time.Duration) *Heartbeat {
+	if config.RenewQuery == "" || lease <= 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	h := &Heartbeat{
+		cancel: cancel,
+		done:   make(chan struct{}),
+		lost:   make(chan struct{}),
+	}
+
+	go h.run(ctx, db, config, lockKey, ownerID, lease)
+
+	return h
+}
+
+// Stop stops renewing the lock and waits for an in-flight renewal to finish.
+func (h *Heartbeat) Stop() {
+	if h == nil {
+		return
+	}
+	h.cancel()
+	<-h.done
+}
+
+// Lost returns a channel that is closed when the lock could not be renewed.
+// Returns nil for a nil Heartbeat, which blocks forever.
+func (h *Heartbeat) Lost() <-chan struct{} {
+	if h == nil {
+		return nil
+	}
+	return h.lost
+}
+
+// Err returns why the lock was lost, or nil if it is still held.
+func (h *Heartbeat) Err() error {
+	if h == nil {
+		return nil
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.err
+}
+
+func (h *Heartbeat) run(ctx context.Context, db *sql.DB, config TableLockConfig, lockKey, ownerID string, lease time.Duration) {
+	defer close(h.done)
+
+	interval := lease / 3
+	validUntil := time.Now().Add(lease)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		expiresAt := time.Now().Add(lease)
+		err := renewTableLock(ctx, db, config, lockKey, ownerID, expiresAt, interval)
+		if err == nil {
+			validUntil = expiresAt
+			continue
+		}
+		if ctx.Err() != nil {
+			// Stopped during renewal
+			return
+		}
+
+		// Retry transient errors while the current lease still protects us
+		if !errors.Is(err, errLockNotHeld) && time.Now().Add(interval).Before(validUntil) {
+			continue
+		}
+
+		h.mu.Lock()
+		h.err = fmt.Errorf("failed to renew lock '%s': %w", lockKey, err)
+		h.mu.Unlock()
+		close(h.lost)
+		return
+	}
+}
+
+// renewTableLock extends the lock and verifies it is still owned by ownerID.
+func renewTableLock(ctx context.Context, db *sql.DB, config TableLockConfig, lockKey, ownerID string, expiresAt time.Time, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	if _, err := db.ExecContext(ctx, config.RenewQuery, expiresAt, lockKey, ownerID); err != nil {
+		return err
+	}
+
+	if config.HeldQuery == "" {
+		return nil
+	}
+
+	held, err := config.ScanFunc(db.QueryRowContext(ctx, config.HeldQuery, lockKey, ownerID))
+	if err != nil && err != sql.ErrNoRows {
+		return err
+	}
+	if !held {
+		return errLockNotHeld
+	}
+
+	return nil
+}
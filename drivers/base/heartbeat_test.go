@@ -0,0 +1,96 @@
+package base
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+func setupLockTable(t *testing.T) (*sql.DB, TableLockConfig) {
+	t.Helper()
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	db.SetMaxOpenConns(1)
+	t.Cleanup(func() { _ = db.Close() })
+
+	if _, err := db.Exec(`CREATE TABLE locks (lock_key TEXT PRIMARY KEY, expires_at TIMESTAMP, owner_id TEXT)`); err != nil {
+		t.Fatalf("failed to create lock table: %v", err)
+	}
+
+	return db, TableLockConfig{
+		RenewQuery: "UPDATE locks SET expires_at = ? WHERE lock_key = ? AND owner_id = ?",
+		HeldQuery:  "SELECT 1 FROM locks WHERE lock_key = ? AND owner_id = ?",
+		ScanFunc: func(row *sql.Row) (bool, error) {
+			var exists int
+			err := row.Scan(&exists)
+			if err != nil && err != sql.ErrNoRows {
+				return false, err
+			}
+			return exists != 0, nil
+		},
+	}
+}
+
+func TestHeartbeat(t *testing.T) {
+	t.Run("renews lease until stopped", func(t *testing.T) {
+		db, cfg := setupLockTable(t)
+		initial := time.Now().Add(time.Second)
+		if _, err := db.Exec(`INSERT INTO locks VALUES ('lock', ?, 'me')`, initial); err != nil {
+			t.Fatal(err)
+		}
+
+		h := StartHeartbeat(db, cfg, "lock", "me", 90*time.Millisecond)
+		time.Sleep(200 * time.Millisecond)
+		h.Stop()
+
+		var expiresAt time.Time
+		if err := db.QueryRow(`SELECT expires_at FROM locks WHERE lock_key = 'lock'`).Scan(&expiresAt); err != nil {
+			t.Fatal(err)
+		}
+		if !expiresAt.Before(initial) {
+			t.Errorf("expires_at was not renewed")
+		}
+
+		select {
+		case <-h.Lost():
+			t.Errorf("lock reported lost: %v", h.Err())
+		default:
+		}
+	})
+
+	t.Run("reports lock taken by another process", func(t *testing.T) {
+		db, cfg := setupLockTable(t)
+		if _, err := db.Exec(`INSERT INTO locks VALUES ('lock', ?, 'other')`, time.Now().Add(time.Minute)); err != nil {
+			t.Fatal(err)
+		}
+
+		h := StartHeartbeat(db, cfg, "lock", "me", 30*time.Millisecond)
+		defer h.Stop()
+
+		select {
+		case <-h.Lost():
+		case <-time.After(time.Second):
+			t.Fatal("lost lock was not reported")
+		}
+		if !errors.Is(h.Err(), errLockNotHeld) {
+			t.Errorf("Err() = %v, want errLockNotHeld", h.Err())
+		}
+	})
+
+	t.Run("nil heartbeat", func(t *testing.T) {
+		h := StartHeartbeat(nil, TableLockConfig{}, "lock", "me", time.Second)
+		if h != nil {
+			t.Fatal("expected nil heartbeat without RenewQuery")
+		}
+		h.Stop()
+		if h.Lost() != nil || h.Err() != nil {
+			t.Error("nil heartbeat must report no loss")
+		}
+	})
+}
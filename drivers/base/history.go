@@ -0,0 +1,166 @@
+package base
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/honeynil/queen"
+)
+
+// HistoryTypes are the database column types of the optional history columns.
+//
+// Drivers that set them get duration_ms, applied_by, hostname, queen_version
+// and build_tag columns in the migrations table, filled from queen.RecordInfo.
+type HistoryTypes struct {
+	// Integer is the type of duration_ms (e.g. "BIGINT", "Nullable(Int64)").
+	Integer string
+
+	// Text is the type of applied_by, hostname, queen_version and build_tag
+	// (e.g. "VARCHAR(255)", "Nullable(String)").
+	Text string
+}
+
+// Column describes a column of the migrations table.
+type Column struct {
+	Name string
+	Type string
+}
+
+// HistoryColumns returns the optional history columns with their configured types,
+// or nil if the driver doesn't define HistoryTypes.
+func (d *Driver) HistoryColumns() []Column {
+	if !d.hasHistory() {
+		return nil
+	}
+
+	t := d.Config.HistoryTypes
+	return []Column{
+		{Name: "duration_ms", Type: t.Integer},
+		{Name: "applied_by", Type: t.Text},
+		{Name: "hostname", Type: t.Text},
+		{Name: "queen_version", Type: t.Text},
+		{Name: "build_tag", Type: t.Text},
+	}
+}
+
+// MissingHistoryColumns returns history columns that don't exist in the
+// migrations table yet, e.g. because it was created by an older Queen version.
+func (d *Driver) MissingHistoryColumns(ctx context.Context) ([]Column, error) {
+	return d.MissingColumns(ctx, d.TableName, d.HistoryColumns())
+}
+
+// UpgradeSchema adds missing history columns to the migrations table.
+//
+// Drivers call it from Init after creating the table. It is idempotent:
+// existing columns are left untouched, so it is safe to run on every start.
+func (d *Driver) UpgradeSchema(ctx context.Context) error {
+	return d.AddMissingColumns(ctx, d.TableName, d.HistoryColumns())
+}
+
+// AddColumnQuery builds the ALTER TABLE statement adding column c to the migrations table.
+func (d *Driver) AddColumnQuery(c Column) string {
+	return d.AddColumnQueryFor(d.TableName, c)
+}
+
+// MissingColumns returns the columns that don't exist in table yet.
+func (d *Driver) MissingColumns(ctx context.Context, table string, columns []Column) ([]Column, error) {
+	if len(columns) == 0 {
+		return nil, nil
+	}
+
+	query := fmt.Sprintf("SELECT * FROM %s WHERE 1 = 0", d.QuoteTable(table))
+	rows, err := d.DB.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read columns of table '%s': %w", table, err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	existing, err := rows.Columns()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read columns of table '%s': %w", table, err)
+	}
+
+	have := make(map[string]bool, len(existing))
+	for _, name := range existing {
+		have[strings.ToLower(name)] = true
+	}
+
+	var missing []Column
+	for _, c := range columns {
+		if !have[c.Name] {
+			missing = append(missing, c)
+		}
+	}
+
+	return missing, nil
+}
+
+// AddMissingColumns adds the columns that don't exist in table yet.
+// It is idempotent, so drivers can run it from Init on every start.
+func (d *Driver) AddMissingColumns(ctx context.Context, table string, columns []Column) error {
+	missing, err := d.MissingColumns(ctx, table, columns)
+	if err != nil {
+		return err
+	}
+
+	for _, c := range missing {
+		if _, err := d.DB.ExecContext(ctx, d.AddColumnQueryFor(table, c)); err != nil {
+			return fmt.Errorf("failed to add column '%s' to table '%s': %w", c.Name, table, err)
+		}
+	}
+
+	return nil
+}
+
+// AddColumnQueryFor builds the ALTER TABLE statement adding column c to table.
+func (d *Driver) AddColumnQueryFor(table string, c Column) string {
+	quotedTable := d.QuoteTable(table)
+	column := d.Config.QuoteIdentifier(c.Name)
+
+	if d.Config.AddColumn != nil {
+		return d.Config.AddColumn(quotedTable, column, c.Type)
+	}
+	return fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s", quotedTable, column, c.Type)
+}
+
+// RecordArgs returns the history column values for a migration record,
+// in the order of HistoryColumns.
+func RecordArgs(ctx context.Context) []any {
+	info, _ := queen.RecordInfoFromContext(ctx)
+	return []any{
+		info.Duration.Milliseconds(),
+		info.AppliedBy,
+		info.Hostname,
+		info.QueenVersion,
+		info.BuildTag,
+	}
+}
+
+func (d *Driver) hasHistory() bool {
+	return d.Config.HistoryTypes.Integer != "" && d.Config.HistoryTypes.Text != ""
+}
+
+// historyScan holds nullable destinations for history columns,
+// which are NULL for rows recorded before the schema upgrade.
+type historyScan struct {
+	durationMs   sql.NullInt64
+	appliedBy    sql.NullString
+	hostname     sql.NullString
+	queenVersion sql.NullString
+	buildTag     sql.NullString
+}
+
+func (h *historyScan) dest() []any {
+	return []any{&h.durationMs, &h.appliedBy, &h.hostname, &h.queenVersion, &h.buildTag}
+}
+
+func (h *historyScan) apply(a *queen.Applied) {
+	a.Duration = time.Duration(h.durationMs.Int64) * time.Millisecond
+	a.AppliedBy = h.appliedBy.String
+	a.Hostname = h.hostname.String
+	a.QueenVersion = h.queenVersion.String
+	a.BuildTag = h.buildTag.String
+}
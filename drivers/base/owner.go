@@ -4,6 +4,7 @@ package base
 import (
 	"crypto/rand"
 	"encoding/hex"
+	"os"
 )
 
 // GenerateOwnerID generates a unique owner identifier for lock ownership tracking.
@@ -30,3 +31,13 @@ func GenerateOwnerID() (string, error) {
 	}
 	return hex.EncodeToString(b), nil
 }
+
+// Hostname returns the host name recorded with table-based locks,
+// or "unknown" if it can't be determined.
+func Hostname() string {
+	name, err := os.Hostname()
+	if err != nil || name == "" {
+		return "unknown"
+	}
+	return name
+}
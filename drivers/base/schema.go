@@ -0,0 +1,35 @@
+package base
+
+// QuoteTable quotes a table name, qualifying it with Schema when set.
+//
+//	QuoteTable("queen_migrations")                  -> "queen_migrations"
+//	QuoteTable("queen_migrations") (Schema: "app")  -> "app"."queen_migrations"
+func (d *Driver) QuoteTable(name string) string {
+	if d.Schema == "" {
+		return d.Config.QuoteIdentifier(name)
+	}
+	return d.Config.QuoteIdentifier(d.Schema) + "." + d.Config.QuoteIdentifier(name)
+}
+
+// SearchPath is a Config.SearchPath strategy for PostgreSQL and CockroachDB.
+//
+// It puts schema in front of public in search_path, so unqualified names in
+// migrations resolve to the schema first. An empty schema resets search_path.
+func SearchPath(schema string, local bool) string {
+	if schema == "" {
+		return "RESET search_path"
+	}
+	if local {
+		return "SET LOCAL search_path TO " + schema + ", public"
+	}
+	return "SET search_path TO " + schema + ", public"
+}
+
+// searchPathQuery returns the statement selecting Schema for a migration,
+// or "" if no schema is configured or the database can't select one.
+func (d *Driver) searchPathQuery(local bool) string {
+	if d.Schema == "" || d.Config.SearchPath == nil {
+		return ""
+	}
+	return d.Config.SearchPath(d.Config.QuoteIdentifier(d.Schema), local)
+}
@@ -0,0 +1,66 @@
+package base
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func TestQuoteTable(t *testing.T) {
+	tests := []struct {
+		name     string
+		schema   string
+		quote    func(string) string
+		expected string
+	}{
+		{"no schema", "", QuoteDoubleQuotes, `"queen_migrations"`},
+		{"schema", "app", QuoteDoubleQuotes, `"app"."queen_migrations"`},
+		{"dotted schema stays one identifier", "my.app", QuoteDoubleQuotes, `"my.app"."queen_migrations"`},
+		{"brackets", "dbo", QuoteBrackets, `[dbo].[queen_migrations]`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &Driver{Schema: tt.schema, Config: Config{QuoteIdentifier: tt.quote}}
+			if got := d.QuoteTable("queen_migrations"); got != tt.expected {
+				t.Errorf("QuoteTable() = %q; want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestExecSelectsSchema(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("failed to open sqlmock: %v", err)
+	}
+	defer db.Close()
+
+	d := &Driver{
+		DB:     db,
+		Schema: "app",
+		Config: Config{QuoteIdentifier: QuoteDoubleQuotes, SearchPath: SearchPath},
+	}
+	ctx := context.Background()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`SET LOCAL search_path TO "app", public`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	if err := d.Exec(ctx, sql.LevelDefault, func(*sql.Tx) error { return nil }); err != nil {
+		t.Fatalf("Exec() error = %v", err)
+	}
+
+	mock.ExpectExec(`SET search_path TO "app", public`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`RESET search_path`).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	if err := d.ExecNoTx(ctx, func(*sql.Conn) error { return nil }); err != nil {
+		t.Fatalf("ExecNoTx() error = %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,263 @@
+// drivers/base/split.go
+package base
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Dialect describes the lexical rules SplitStatements needs to find
+// statement boundaries without breaking quoted strings, comments or
+// procedural blocks.
+//
+// Drivers whose database rejects several statements in one Exec call
+// implement queen.StatementSplitter by calling SplitStatements with
+// their dialect.
+type Dialect struct {
+	// DollarQuotes enables PostgreSQL dollar-quoted strings ($$...$$, $tag$...$tag$).
+	DollarQuotes bool
+
+	// HashComments treats '#' as the start of a line comment (MySQL).
+	HashComments bool
+
+	// BackslashEscapes treats '\' as an escape character inside string
+	// literals (MySQL, ClickHouse).
+	BackslashEscapes bool
+
+	// BeginEndBlocks keeps BEGIN...END bodies of triggers and procedures
+	// in a single statement.
+	BeginEndBlocks bool
+
+	// BatchSeparator, if set, splits only on lines consisting of this keyword
+	// instead of on semicolons (MS SQL Server "GO").
+	BatchSeparator string
+}
+
+// Predefined dialects for the bundled drivers.
+var (
+	DialectPostgres   = Dialect{DollarQuotes: true, BeginEndBlocks: true}
+	DialectMySQL      = Dialect{HashComments: true, BackslashEscapes: true, BeginEndBlocks: true}
+	DialectSQLite     = Dialect{BeginEndBlocks: true}
+	DialectClickHouse = Dialect{BackslashEscapes: true}
+	DialectYDB        = Dialect{}
+	DialectMSSQL      = Dialect{BatchSeparator: "GO"}
+)
+
+// SplitStatements splits a SQL script into individual statements.
+//
+// Statements are separated by semicolons, or by BatchSeparator lines when the
+// dialect defines one. Separators inside quoted strings, identifiers,
+// comments, dollar-quoted bodies and BEGIN...END blocks are ignored.
+// Returned statements are trimmed and have no trailing semicolon; segments
+// that contain only whitespace or comments are dropped.
+//
+// Examples:
+//
+//	SplitStatements("CREATE TABLE a (id INT); CREATE TABLE b (id INT);", DialectMySQL)
+//	    -> ["CREATE TABLE a (id INT)", "CREATE TABLE b (id INT)"]
+//
+//	SplitStatements("CREATE TABLE a (id INT)\nGO\nCREATE TABLE b (id INT)", DialectMSSQL)
+//	    -> ["CREATE TABLE a (id INT)", "CREATE TABLE b (id INT)"]
+func SplitStatements(query string, dialect Dialect) ([]string, error) {
+	s := splitter{query: query, dialect: dialect}
+	if err := s.run(); err != nil {
+		return nil, err
+	}
+	return s.statements, nil
+}
+
+// splitter holds the scanning state of SplitStatements.
+type splitter struct {
+	query   string
+	dialect Dialect
+
+	statements []string
+	start      int  // start offset of the current statement
+	hasCode    bool // current statement contains more than whitespace and comments
+	depth      int  // BEGIN...END nesting depth
+}
+
+func (s *splitter) run() error {
+	q := s.query
+	lineStart := true
+
+	for i := 0; i < len(q); {
+		c := q[i]
+
+		if lineStart && s.dialect.BatchSeparator != "" {
+			end := lineEnd(q, i)
+			if strings.EqualFold(strings.TrimSpace(q[i:end]), s.dialect.BatchSeparator) {
+				s.flush(i)
+				s.start = end
+				i = end
+				continue
+			}
+		}
+		lineStart = c == '\n'
+
+		switch {
+		case c == '-' && strings.HasPrefix(q[i:], "--"),
+			c == '#' && s.dialect.HashComments:
+			i = lineEnd(q, i)
+
+		case c == '/' && strings.HasPrefix(q[i:], "/*"):
+			end := strings.Index(q[i+2:], "*/")
+			if end < 0 {
+				return fmt.Errorf("unterminated block comment at offset %d", i)
+			}
+			i += end + 4
+
+		case c == '\'' || c == '"' || c == '`':
+			end, err := s.skipQuoted(i)
+			if err != nil {
+				return err
+			}
+			s.hasCode = true
+			i = end
+
+		case c == '$' && s.dialect.DollarQuotes && (i == 0 || !isIdentChar(q[i-1])):
+			tag := dollarTag(q[i:])
+			if tag == "" {
+				s.hasCode = true
+				i++
+				continue
+			}
+			end := strings.Index(q[i+len(tag):], tag)
+			if end < 0 {
+				return fmt.Errorf("unterminated dollar-quoted string %s at offset %d", tag, i)
+			}
+			s.hasCode = true
+			i += len(tag) + end + len(tag)
+
+		case isIdentChar(c):
+			end := i
+			for end < len(q) && isIdentChar(q[end]) {
+				end++
+			}
+			if s.dialect.BeginEndBlocks {
+				end = s.trackBlock(strings.ToUpper(q[i:end]), end)
+			}
+			s.hasCode = true
+			i = end
+
+		case c == ';' && s.dialect.BatchSeparator == "" && s.depth == 0:
+			s.flush(i)
+			s.start = i + 1
+			i++
+
+		default:
+			if !isSpace(c) {
+				s.hasCode = true
+			}
+			i++
+		}
+	}
+
+	s.flush(len(q))
+	return nil
+}
+
+// flush appends query[start:end] as a statement if it contains code.
+func (s *splitter) flush(end int) {
+	if s.hasCode {
+		s.statements = append(s.statements, strings.TrimSpace(s.query[s.start:end]))
+	}
+	s.hasCode = false
+}
+
+// skipQuoted returns the offset just past the quoted literal starting at i.
+// Doubled quote characters are treated as escaped quotes.
+func (s *splitter) skipQuoted(i int) (int, error) {
+	q := s.query
+	quote := q[i]
+
+	for j := i + 1; j < len(q); j++ {
+		switch {
+		case q[j] == '\\' && s.dialect.BackslashEscapes && quote != '`':
+			j++
+		case q[j] == quote:
+			if j+1 < len(q) && q[j+1] == quote {
+				j++
+				continue
+			}
+			return j + 1, nil
+		}
+	}
+
+	return 0, fmt.Errorf("unterminated quoted string %c at offset %d", quote, i)
+}
+
+// trackBlock updates the BEGIN...END nesting depth for keyword ending at offset end.
+// It returns the offset to continue scanning from, which is past the CASE of
+// END CASE so that it doesn't open another level.
+func (s *splitter) trackBlock(word string, end int) int {
+	switch word {
+	case "BEGIN":
+		// BEGIN; / BEGIN TRANSACTION start a transaction, not a block
+		switch next, _ := nextWord(s.query, end); next {
+		case "", "TRANSACTION", "WORK", "DEFERRED", "IMMEDIATE", "EXCLUSIVE", "TRAN":
+			return end
+		}
+		s.depth++
+	case "CASE":
+		s.depth++
+	case "END":
+		// END IF / END LOOP / ... close blocks that never opened a level
+		switch next, nextEnd := nextWord(s.query, end); next {
+		case "IF", "LOOP", "WHILE", "REPEAT", "FOR":
+			return end
+		case "CASE":
+			// END CASE closes the level opened by CASE
+			end = nextEnd
+		}
+		if s.depth > 0 {
+			s.depth--
+		}
+	}
+	return end
+}
+
+// nextWord returns the upper-cased identifier following offset i and the
+// offset just past it, or "" if the next non-space character does not start
+// an identifier.
+func nextWord(q string, i int) (string, int) {
+	for i < len(q) && isSpace(q[i]) {
+		i++
+	}
+	end := i
+	for end < len(q) && isIdentChar(q[end]) {
+		end++
+	}
+	return strings.ToUpper(q[i:end]), end
+}
+
+// dollarTag returns the opening dollar-quote tag at the start of s ($$ or $tag$),
+// or "" if s does not start with one (e.g. a $1 placeholder).
+func dollarTag(s string) string {
+	for j := 1; j < len(s); j++ {
+		c := s[j]
+		if c == '$' {
+			return s[:j+1]
+		}
+		if !isIdentChar(c) || (j == 1 && c >= '0' && c <= '9') {
+			return ""
+		}
+	}
+	return ""
+}
+
+// lineEnd returns the offset of the next newline at or after i, or len(q).
+func lineEnd(q string, i int) int {
+	if n := strings.IndexByte(q[i:], '\n'); n >= 0 {
+		return i + n
+	}
+	return len(q)
+}
+
+func isIdentChar(c byte) bool {
+	return c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9'
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\f' || c == '\v'
+}
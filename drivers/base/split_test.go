@@ -0,0 +1,172 @@
+package base
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSplitStatements(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   string
+		dialect Dialect
+		want    []string
+	}{
+		{
+			name:    "single statement",
+			query:   "CREATE TABLE users (id INT)",
+			dialect: DialectMySQL,
+			want:    []string{"CREATE TABLE users (id INT)"},
+		},
+		{
+			name:    "multiple statements",
+			query:   "CREATE TABLE a (id INT);\nCREATE TABLE b (id INT);\n",
+			dialect: DialectMySQL,
+			want:    []string{"CREATE TABLE a (id INT)", "CREATE TABLE b (id INT)"},
+		},
+		{
+			name:    "semicolons in quotes",
+			query:   `INSERT INTO t VALUES ('a;b', "c;d", 'it''s;'); SELECT ` + "`x;y`" + ` FROM t`,
+			dialect: DialectMySQL,
+			want:    []string{`INSERT INTO t VALUES ('a;b', "c;d", 'it''s;')`, "SELECT `x;y` FROM t"},
+		},
+		{
+			name:    "backslash escapes",
+			query:   `INSERT INTO t VALUES ('a\';b'); SELECT 1`,
+			dialect: DialectMySQL,
+			want:    []string{`INSERT INTO t VALUES ('a\';b')`, "SELECT 1"},
+		},
+		{
+			name:    "comments",
+			query:   "-- first; table\nCREATE TABLE a (id INT); /* block; comment */\n# hash; comment\nSELECT 1;\n-- trailing comment",
+			dialect: DialectMySQL,
+			want:    []string{"-- first; table\nCREATE TABLE a (id INT)", "/* block; comment */\n# hash; comment\nSELECT 1"},
+		},
+		{
+			name:    "empty statements dropped",
+			query:   ";;  ;\n-- only a comment;\n",
+			dialect: DialectMySQL,
+			want:    nil,
+		},
+		{
+			name: "mysql trigger",
+			query: `CREATE TRIGGER trg BEFORE INSERT ON t FOR EACH ROW
+BEGIN
+    IF NEW.a < 0 THEN
+        SET NEW.a = 0;
+    END IF;
+    SET NEW.b = CASE WHEN NEW.a > 0 THEN 1 ELSE 0 END;
+END;
+SELECT 1;`,
+			dialect: DialectMySQL,
+			want: []string{`CREATE TRIGGER trg BEFORE INSERT ON t FOR EACH ROW
+BEGIN
+    IF NEW.a < 0 THEN
+        SET NEW.a = 0;
+    END IF;
+    SET NEW.b = CASE WHEN NEW.a > 0 THEN 1 ELSE 0 END;
+END`, "SELECT 1"},
+		},
+		{
+			name: "mysql procedure with case statement",
+			query: `CREATE PROCEDURE p(IN x INT)
+BEGIN
+    CASE x
+        WHEN 1 THEN SELECT 'one';
+        ELSE SELECT 'other';
+    end case;
+END;
+SELECT 1;
+SELECT 2;`,
+			dialect: DialectMySQL,
+			want: []string{`CREATE PROCEDURE p(IN x INT)
+BEGIN
+    CASE x
+        WHEN 1 THEN SELECT 'one';
+        ELSE SELECT 'other';
+    end case;
+END`, "SELECT 1", "SELECT 2"},
+		},
+		{
+			name:    "begin transaction is not a block",
+			query:   "BEGIN; UPDATE t SET a = 1; COMMIT;",
+			dialect: DialectSQLite,
+			want:    []string{"BEGIN", "UPDATE t SET a = 1", "COMMIT"},
+		},
+		{
+			name: "postgres dollar quotes",
+			query: `CREATE FUNCTION f() RETURNS trigger AS $body$
+BEGIN
+    RAISE NOTICE 'x;y';
+    RETURN NEW;
+END;
+$body$ LANGUAGE plpgsql;
+SELECT $$a;b$$, $1;`,
+			dialect: DialectPostgres,
+			want: []string{`CREATE FUNCTION f() RETURNS trigger AS $body$
+BEGIN
+    RAISE NOTICE 'x;y';
+    RETURN NEW;
+END;
+$body$ LANGUAGE plpgsql`, "SELECT $$a;b$$, $1"},
+		},
+		{
+			name:    "ydb variables are not dollar quotes",
+			query:   "DECLARE $id AS Int32; SELECT * FROM t WHERE id = $id;",
+			dialect: DialectYDB,
+			want:    []string{"DECLARE $id AS Int32", "SELECT * FROM t WHERE id = $id"},
+		},
+		{
+			name: "mssql GO batches",
+			query: `CREATE TABLE a (id INT);
+CREATE TABLE b (id INT);
+GO
+CREATE PROCEDURE p AS
+BEGIN
+    SELECT 'GO';
+END
+  go
+/* GO
+*/
+SELECT 1`,
+			dialect: DialectMSSQL,
+			want: []string{
+				"CREATE TABLE a (id INT);\nCREATE TABLE b (id INT);",
+				"CREATE PROCEDURE p AS\nBEGIN\n    SELECT 'GO';\nEND",
+				"/* GO\n*/\nSELECT 1",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := SplitStatements(tt.query, tt.dialect)
+			if err != nil {
+				t.Fatalf("SplitStatements() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SplitStatements() = %q; want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSplitStatementsErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   string
+		dialect Dialect
+	}{
+		{"unterminated string", "SELECT 'abc; SELECT 1", DialectMySQL},
+		{"unterminated block comment", "SELECT 1; /* comment", DialectMySQL},
+		{"unterminated dollar quote", "SELECT $tag$ abc", DialectPostgres},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := SplitStatements(tt.query, tt.dialect); err == nil {
+				t.Error("SplitStatements() error = nil; want error")
+			}
+		})
+	}
+}
@@ -4,6 +4,7 @@ package base
 import (
 	"context"
 	"database/sql"
+	"fmt"
 	"time"
 
 	"github.com/honeynil/queen"
@@ -30,9 +31,22 @@ type TableLockConfig struct {
 	// InsertQuery inserts a new lock entry.
 	InsertQuery string
 
-	// ScanFunc processes the result of CheckQuery and returns true if
-	// a lock exists, false otherwise. Returns an error if SQL execution failed.
+	// ScanFunc processes the result of CheckQuery and HeldQuery and returns
+	// true if a lock exists, false otherwise. Returns an error if SQL
+	// execution failed.
 	ScanFunc func(*sql.Row) (bool, error)
+
+	// RenewQuery extends the expiration time of a lock held by this process.
+	// Used by Heartbeat; if empty, the lock is not renewed.
+	RenewQuery string
+
+	// HeldQuery checks that the lock still belongs to this process after
+	// renewal, regardless of its expiration time.
+	HeldQuery string
+
+	// Hostname of this process. If set, it is passed to InsertQuery as a
+	// fourth parameter so LockInspector can report who holds the lock.
+	Hostname string
 }
 
 // AcquireTableLock implements distributed locking using a lock table.
@@ -53,7 +67,9 @@ type TableLockConfig struct {
 // Query parameters:
 // - CleanupQuery: receives (lockKey, expiresAt)
 // - CheckQuery: receives (lockKey)
-// - InsertQuery: receives (lockKey, expiresAt, ownerID)
+// - InsertQuery: receives (lockKey, expiresAt, ownerID), plus Hostname if set
+// - RenewQuery: receives (expiresAt, lockKey, ownerID)
+// - HeldQuery: receives (lockKey, ownerID)
 //
 // Exponential backoff:
 // - Starts at 50ms and doubles after each retry
@@ -81,7 +97,11 @@ func AcquireTableLock(ctx context.Context, db *sql.DB, config TableLockConfig, l
 
 		// Step 3: if no lock exists, try to insert
 		if !hasLock {
-			_, err := db.ExecContext(ctx, config.InsertQuery, lockKey, expiresAt, ownerID)
+			args := []any{lockKey, expiresAt, ownerID}
+			if config.Hostname != "" {
+				args = append(args, config.Hostname)
+			}
+			_, err := db.ExecContext(ctx, config.InsertQuery, args...)
 			if err == nil {
 				// Lock acquired successfully
 				return nil
@@ -107,3 +127,29 @@ func AcquireTableLock(ctx context.Context, db *sql.DB, config TableLockConfig, l
 		}
 	}
 }
+
+// TableLockInfo reads the holder of a table-based lock for LockInspector.
+//
+// The query receives (lockKey) and must select owner_id, hostname,
+// acquired_at and expires_at. Returns nil if the lock is free.
+func TableLockInfo(ctx context.Context, db *sql.DB, query, lockKey string) (*queen.LockInfo, error) {
+	var (
+		owner      string
+		hostname   sql.NullString
+		acquiredAt sql.NullTime
+		info       = queen.LockInfo{Key: lockKey}
+	)
+
+	err := db.QueryRowContext(ctx, query, lockKey).Scan(&owner, &hostname, &acquiredAt, &info.ExpiresAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read lock '%s': %w", lockKey, err)
+	}
+
+	info.Owner = owner
+	info.Host = hostname.String
+	info.AcquiredAt = acquiredAt.Time
+	return &info, nil
+}
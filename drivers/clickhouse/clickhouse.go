@@ -17,6 +17,7 @@ type Driver struct {
 	lockTableName string
 	lockKey       string
 	ownerID       string
+	heartbeat     *base.Heartbeat
 }
 
 // New creates a new ClickHouse driver.
@@ -64,6 +65,9 @@ func NewWithTableName(db *sql.DB, tableName string) (*Driver, error) {
 				Placeholder:     base.PlaceholderQuestion,
 				QuoteIdentifier: base.QuoteDoubleQuotes,
 				ParseTime:       nil,
+				// ClickHouse has no transactional DDL; record after Exec.
+				TransactionalDDL: false,
+				HistoryTypes:     base.HistoryTypes{Integer: "Nullable(Int64)", Text: "Nullable(String)"},
 			},
 		},
 		lockTableName: tableName + "_lock",
@@ -79,11 +83,18 @@ func NewWithTableName(db *sql.DB, tableName string) (*Driver, error) {
 //   - name:        LowCardinality(String) - human-readable migration name
 //   - applied_at:  DateTime64(3)     DEFAULT now64(3) - when the migration was applied
 //   - checksum:    String            DEFAULT ” - hash of migration content for validation
+//   - duration_ms, applied_by, hostname, queen_version, build_tag - history
+//     columns, added to tables created by older versions automatically
+//
+// It also creates the audit table (see base.Driver.AuditTableName) and the
+// dirty marker table (see base.Driver.DirtyTableName).
 //
 // The lock table schema:
 //   - lock_key:    LowCardinality(String) - lock identifier
 //   - acquired_at: DateTime64(3)     - when the lock was acquired
 //   - expires_at:  DateTime64(3)     - when the lock expires
+//   - owner_id:    String            - process holding the lock
+//   - hostname:    String            - host of that process (added automatically)
 //   - TTL: expires_at + 10 SECOND    - automatically removes expired locks
 //
 // The TTL (Time To Live) on the lock table provides automatic cleanup of expired
@@ -107,20 +118,65 @@ func (d *Driver) Init(ctx context.Context) error {
 		return err
 	}
 
+	if err := d.UpgradeSchema(ctx); err != nil {
+		return err
+	}
+
+	auditQuery := fmt.Sprintf(`
+		CREATE TABLE IF NOT EXISTS %s (
+			version       String,
+			name          String,
+			direction     LowCardinality(String),
+			outcome       LowCardinality(String),
+			error_message String,
+			duration_ms   Int64,
+			applied_by    String,
+			hostname      String,
+			queen_version String,
+			build_tag     String,
+			executed_at   DateTime64(6)     DEFAULT now64(6)
+		)
+		ENGINE = MergeTree()
+		ORDER BY executed_at
+	`, d.Config.QuoteIdentifier(d.AuditTableName()))
+
+	if _, err := d.DB.ExecContext(ctx, auditQuery); err != nil {
+		return err
+	}
+
+	dirtyQuery := fmt.Sprintf(`
+		CREATE TABLE IF NOT EXISTS %s (
+			version     String,
+			name        String,
+			direction   LowCardinality(String),
+			started_at  DateTime64(3)     DEFAULT now64(3)
+		)
+		ENGINE = ReplacingMergeTree()
+		ORDER BY version
+	`, d.Config.QuoteIdentifier(d.DirtyTableName()))
+
+	if _, err := d.DB.ExecContext(ctx, dirtyQuery); err != nil {
+		return err
+	}
+
 	lockQuery := fmt.Sprintf(`
 		CREATE TABLE IF NOT EXISTS %s (
 			lock_key    LowCardinality(String),
 			acquired_at DateTime64(3)     DEFAULT now64(3),
 			expires_at  DateTime64(3),
-			owner_id    String
+			owner_id    String,
+			hostname    String            DEFAULT ''
 		)
 		ENGINE = ReplacingMergeTree()
 		ORDER BY lock_key
 		TTL expires_at + INTERVAL 10 SECOND DELETE
 	`, d.Config.QuoteIdentifier(d.lockTableName))
 
-	_, err := d.DB.ExecContext(ctx, lockQuery)
-	return err
+	if _, err := d.DB.ExecContext(ctx, lockQuery); err != nil {
+		return err
+	}
+
+	return d.AddMissingColumns(ctx, d.lockTableName, []base.Column{{Name: "hostname", Type: "String DEFAULT ''"}})
 }
 
 // Lock acquires a distributed lock to prevent concurrent migrations.
@@ -134,6 +190,7 @@ func (d *Driver) Init(ctx context.Context) error {
 // 2. Checks if an active lock exists using SELECT with FINAL
 // 3. If no lock exists, attempts INSERT
 // 4. Retries with exponential backoff until timeout or lock is acquired
+// 5. Renews expires_at every timeout/3 until Unlock (see base.Heartbeat)
 //
 // IMPORTANT: Uses FINAL modifier with ReplacingMergeTree to ensure we see
 // deduplicated data, not intermediate merge states. This is critical because
@@ -154,9 +211,18 @@ func (d *Driver) Lock(ctx context.Context, timeout time.Duration) error {
 			d.Config.QuoteIdentifier(d.lockTableName),
 		),
 		InsertQuery: fmt.Sprintf(
-			"INSERT INTO %s (lock_key, expires_at, owner_id) VALUES (?, ?, ?)",
+			"INSERT INTO %s (lock_key, expires_at, owner_id, hostname) VALUES (?, ?, ?, ?)",
+			d.Config.QuoteIdentifier(d.lockTableName),
+		),
+		RenewQuery: fmt.Sprintf(
+			"ALTER TABLE %s UPDATE expires_at = ? WHERE lock_key = ? AND owner_id = ? SETTINGS mutations_sync = 1",
 			d.Config.QuoteIdentifier(d.lockTableName),
 		),
+		HeldQuery: fmt.Sprintf(
+			"SELECT count(*) FROM %s FINAL WHERE lock_key = ? AND owner_id = ?",
+			d.Config.QuoteIdentifier(d.lockTableName),
+		),
+		Hostname: base.Hostname(),
 		ScanFunc: func(row *sql.Row) (bool, error) {
 			var count int64
 			if err := row.Scan(&count); err != nil {
@@ -171,14 +237,18 @@ func (d *Driver) Lock(ctx context.Context, timeout time.Duration) error {
 		return fmt.Errorf("%w: failed to acquire lock '%s' for table '%s'",
 			queen.ErrLockTimeout, d.lockKey, d.lockTableName)
 	}
-	return err
+	if err != nil {
+		return err
+	}
 
+	d.heartbeat = base.StartHeartbeat(d.DB, cfg, d.lockKey, d.ownerID, timeout)
+	return nil
 }
 
 // Unlock releases the migration lock.
 //
 // This removes the lock record from the lock table, allowing other processes
-// to acquire the lock.
+// to acquire the lock. It also stops the lease renewal started by Lock.
 //
 // The unlock operation checks the owner_id to ensure only the process that
 // acquired the lock can release it. This prevents race conditions where an
@@ -188,6 +258,9 @@ func (d *Driver) Lock(ctx context.Context, timeout time.Duration) error {
 // already released, or belongs to another process. This prevents errors
 // during cleanup when locks expire via TTL or in error recovery scenarios.
 func (d *Driver) Unlock(ctx context.Context) error {
+	d.heartbeat.Stop()
+	d.heartbeat = nil
+
 	unlockQuery := fmt.Sprintf(
 		"ALTER TABLE %s DELETE WHERE lock_key = ? AND owner_id = ?",
 		d.Config.QuoteIdentifier(d.lockTableName),
@@ -204,3 +277,70 @@ func (d *Driver) Unlock(ctx context.Context) error {
 	// been released by another cleanup process, or belong to another process
 	return err
 }
+
+// LockInfo returns the current holder of the migration lock, or nil if the
+// lock is free. It implements queen.LockInspector.
+func (d *Driver) LockInfo(ctx context.Context) (*queen.LockInfo, error) {
+	query := fmt.Sprintf(
+		"SELECT owner_id, hostname, acquired_at, expires_at FROM %s FINAL WHERE lock_key = ?",
+		d.Config.QuoteIdentifier(d.lockTableName),
+	)
+	return base.TableLockInfo(ctx, d.DB, query, d.lockKey)
+}
+
+// GetDirty returns all dirty markers sorted by started_at in ascending order.
+//
+// The dirty table is a ReplacingMergeTree, so FINAL is required to collapse
+// rows for the same version that haven't been merged yet.
+func (d *Driver) GetDirty(ctx context.Context) ([]queen.DirtyMigration, error) {
+	query := fmt.Sprintf(
+		"SELECT version, name, direction, started_at FROM %s FINAL ORDER BY started_at ASC",
+		d.Config.QuoteIdentifier(d.DirtyTableName()),
+	)
+	return d.QueryDirty(ctx, query)
+}
+
+// ForceUnlock removes the lock record regardless of its owner.
+func (d *Driver) ForceUnlock(ctx context.Context) error {
+	query := fmt.Sprintf(
+		"ALTER TABLE %s DELETE WHERE lock_key = ? SETTINGS mutations_sync = 1",
+		d.Config.QuoteIdentifier(d.lockTableName),
+	)
+	_, err := d.DB.ExecContext(ctx, query, d.lockKey)
+	return err
+}
+
+// LockLost returns a channel that is closed when the lock lease could not be
+// renewed. It implements queen.LeaseLocker.
+func (d *Driver) LockLost() <-chan struct{} {
+	return d.heartbeat.Lost()
+}
+
+// LockErr returns why the lock lease was lost.
+func (d *Driver) LockErr() error {
+	return d.heartbeat.Err()
+}
+
+// UpdateChecksum replaces the stored checksum of an applied migration.
+//
+// ClickHouse has no UPDATE statement; the row is changed with a mutation,
+// and mutations_sync makes the query wait until it is applied.
+func (d *Driver) UpdateChecksum(ctx context.Context, version, checksum string) error {
+	query := fmt.Sprintf(`
+		ALTER TABLE %s
+		UPDATE checksum = ?
+		WHERE version = ?
+		SETTINGS mutations_sync = 1
+	`, d.Config.QuoteIdentifier(d.TableName))
+
+	_, err := d.DB.ExecContext(ctx, query, checksum, version)
+	return err
+}
+
+// SplitStatements splits a migration script into individual statements.
+//
+// ClickHouse executes exactly one statement per query, so Queen runs
+// multi-statement migrations statement by statement.
+func (d *Driver) SplitStatements(query string) ([]string, error) {
+	return base.SplitStatements(query, base.DialectClickHouse)
+}
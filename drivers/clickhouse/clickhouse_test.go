@@ -139,6 +139,8 @@ func setupTestDB(t *testing.T) (*sql.DB, func()) {
 		// Drop all test tables
 		_, _ = db.Exec("DROP TABLE IF EXISTS queen_migrations")
 		_, _ = db.Exec("DROP TABLE IF EXISTS queen_migrations_lock")
+		_, _ = db.Exec("DROP TABLE IF EXISTS queen_migrations_audit")
+		_, _ = db.Exec("DROP TABLE IF EXISTS queen_migrations_dirty")
 		_, _ = db.Exec("DROP TABLE IF EXISTS test_users")
 		_, _ = db.Exec("DROP TABLE IF EXISTS test_posts")
 		db.Close()
@@ -308,6 +310,38 @@ func TestRemove(t *testing.T) {
 	}
 }
 
+func TestGetDirty_CollapsesUnmergedRows(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	driver, err := New(db)
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+	ctx := context.Background()
+
+	if err := driver.Init(ctx); err != nil {
+		t.Fatalf("Init() failed: %v", err)
+	}
+
+	// Two inserts for the same version land in separate parts until
+	// ReplacingMergeTree merges them in the background.
+	m := &queen.Migration{Version: "001", Name: "create_users"}
+	for range 2 {
+		if err := driver.SetDirty(ctx, m, "up"); err != nil {
+			t.Fatalf("SetDirty() failed: %v", err)
+		}
+	}
+
+	dirty, err := driver.GetDirty(ctx)
+	if err != nil {
+		t.Fatalf("GetDirty() failed: %v", err)
+	}
+	if len(dirty) != 1 || dirty[0].Version != "001" {
+		t.Errorf("GetDirty() = %+v, want one marker for 001", dirty)
+	}
+}
+
 func TestLocking(t *testing.T) {
 	db, cleanup := setupTestDB(t)
 	defer cleanup()
@@ -5,6 +5,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/honeynil/queen"
@@ -17,6 +18,35 @@ type Driver struct {
 	lockTableName string
 	lockKey       string
 	ownerID       string
+	heartbeat     *base.Heartbeat
+}
+
+// Option configures a CockroachDB driver.
+type Option func(*Driver)
+
+// WithLockKey sets the key of the lock row.
+//
+// The lock table is per migrations table, so the default key only needs
+// changing when several services share one lock table on purpose.
+func WithLockKey(key string) Option {
+	return func(d *Driver) {
+		d.lockKey = key
+	}
+}
+
+// WithLockID sets the key of the lock row to the decimal form of id.
+// It mirrors postgres.WithLockID for code that configures both drivers.
+func WithLockID(id int64) Option {
+	return WithLockKey(strconv.FormatInt(id, 10))
+}
+
+// WithSchema keeps the migrations table, its companion tables and the lock
+// table in schema, creating it if missing. Migrations run with schema first
+// in search_path, so unqualified names in them refer to schema.
+func WithSchema(schema string) Option {
+	return func(d *Driver) {
+		d.Schema = schema
+	}
 }
 
 // New creates a new CockroachDB driver.
@@ -36,8 +66,8 @@ type Driver struct {
 //	if err != nil {
 //	    log.Fatal(err)
 //	}
-func New(db *sql.DB) (*Driver, error) {
-	return NewWithTableName(db, "queen_migrations")
+func New(db *sql.DB, opts ...Option) (*Driver, error) {
+	return NewWithTableName(db, "queen_migrations", opts...)
 }
 
 // NewWithTableName creates a new CockroachDB driver with a custom table name.
@@ -52,43 +82,67 @@ func New(db *sql.DB) (*Driver, error) {
 //	if err != nil {
 //	    log.Fatal(err)
 //	}
-func NewWithTableName(db *sql.DB, tableName string) (*Driver, error) {
+func NewWithTableName(db *sql.DB, tableName string, opts ...Option) (*Driver, error) {
 	ownerID, err := base.GenerateOwnerID()
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate lock owner ID: %w", err)
 	}
 
-	return &Driver{
+	d := &Driver{
 		Driver: base.Driver{
 			DB:        db,
 			TableName: tableName,
 			Config: base.Config{
-				Placeholder:     base.PlaceholderDollar,
-				QuoteIdentifier: base.QuoteDoubleQuotes,
-				ParseTime:       nil,
+				Placeholder:      base.PlaceholderDollar,
+				QuoteIdentifier:  base.QuoteDoubleQuotes,
+				ParseTime:        nil,
+				TransactionalDDL: true,
+				HistoryTypes:     base.HistoryTypes{Integer: "BIGINT", Text: "VARCHAR(255)"},
+				SearchPath:       base.SearchPath,
 			},
 		},
 		lockTableName: tableName + "_lock",
 		lockKey:       "migration_lock",
 		ownerID:       ownerID,
-	}, nil
+	}
+
+	for _, opt := range opts {
+		opt(d)
+	}
+
+	return d, nil
 }
 
-// Init creates the migrations tracking table and lock table if they don't exist.
+// Init creates the schema (see WithSchema), the migrations tracking table and
+// lock table if they don't exist.
 //
 // The migrations table schema:
 //   - version:     VARCHAR(255)	PRIMARY KEY - unique migration version
 //   - name:        VARCHAR(255)	NOT NULL - human-readable migration name
 //   - applied_at:  TIMESTAMP		NOT NULL DEFAULT CURRENT_TIMESTAMP - when the migration was applied
 //   - checksum:    VARCHAR(64)		NOT NULL - hash of migration content for validation
+//   - duration_ms, applied_by, hostname, queen_version, build_tag - history
+//     columns, added to tables created by older versions automatically
+//
+// It also creates the audit table (see base.Driver.AuditTableName) and the
+// dirty marker table (see base.Driver.DirtyTableName).
 //
 // The lock table schema:
 //   - lock_key:    VARCHAR(255)	PRIMARY KEY - lock identifier
 //   - acquired_at: TIMESTAMP		NOT NULL DEFAULT CURRENT_TIMESTAMP - when the lock was acquired
 //   - expires_at:  TIMESTAMP		NOT NULL - when the lock expires
+//   - owner_id:    VARCHAR(64)		NOT NULL - process holding the lock
+//   - hostname:    VARCHAR(255)	- host of that process (added automatically)
 //
 // This method is idempotent and safe to call multiple times.
 func (d *Driver) Init(ctx context.Context) error {
+	if d.Schema != "" {
+		query := "CREATE SCHEMA IF NOT EXISTS " + d.Config.QuoteIdentifier(d.Schema)
+		if _, err := d.DB.ExecContext(ctx, query); err != nil {
+			return fmt.Errorf("failed to create schema '%s': %w", d.Schema, err)
+		}
+	}
+
 	migrationsQuery := fmt.Sprintf(`
 		CREATE TABLE IF NOT EXISTS %s (
 			version		VARCHAR(255) PRIMARY KEY,
@@ -96,23 +150,64 @@ func (d *Driver) Init(ctx context.Context) error {
 			applied_at  TIMESTAMP	 DEFAULT CURRENT_TIMESTAMP,
 			checksum	VARCHAR(64)  NOT NULL
 		)
-	`, d.Config.QuoteIdentifier(d.TableName))
+	`, d.QuoteTable(d.TableName))
 
 	if _, err := d.DB.ExecContext(ctx, migrationsQuery); err != nil {
 		return err
 	}
 
+	if err := d.UpgradeSchema(ctx); err != nil {
+		return err
+	}
+
+	auditQuery := fmt.Sprintf(`
+		CREATE TABLE IF NOT EXISTS %s (
+			version			VARCHAR(255)	NOT NULL,
+			name			VARCHAR(255)	NOT NULL,
+			direction		VARCHAR(16)		NOT NULL,
+			outcome			VARCHAR(16)		NOT NULL,
+			error_message	STRING			NOT NULL,
+			duration_ms		INT8			NOT NULL,
+			applied_by		VARCHAR(255)	NOT NULL,
+			hostname		VARCHAR(255)	NOT NULL,
+			queen_version	VARCHAR(255)	NOT NULL,
+			build_tag		VARCHAR(255)	NOT NULL,
+			executed_at		TIMESTAMP		NOT NULL DEFAULT CURRENT_TIMESTAMP
+		)
+	`, d.QuoteTable(d.AuditTableName()))
+
+	if _, err := d.DB.ExecContext(ctx, auditQuery); err != nil {
+		return err
+	}
+
+	dirtyQuery := fmt.Sprintf(`
+		CREATE TABLE IF NOT EXISTS %s (
+			version		VARCHAR(255)	PRIMARY KEY,
+			name		VARCHAR(255)	NOT NULL,
+			direction	VARCHAR(16)		NOT NULL,
+			started_at	TIMESTAMP		NOT NULL DEFAULT CURRENT_TIMESTAMP
+		)
+	`, d.QuoteTable(d.DirtyTableName()))
+
+	if _, err := d.DB.ExecContext(ctx, dirtyQuery); err != nil {
+		return err
+	}
+
 	lockQuery := fmt.Sprintf(`
 		CREATE TABLE IF NOT EXISTS %s (
 			lock_key	VARCHAR(255)	PRIMARY KEY,
 			acquired_at	TIMESTAMP		DEFAULT CURRENT_TIMESTAMP,
 			expires_at	TIMESTAMP		NOT NULL,
-			owner_id	VARCHAR(64)		NOT NULL
+			owner_id	VARCHAR(64)		NOT NULL,
+			hostname	VARCHAR(255)
 		)
-	`, d.Config.QuoteIdentifier(d.lockTableName))
+	`, d.QuoteTable(d.lockTableName))
 
-	_, err := d.DB.ExecContext(ctx, lockQuery)
-	return err
+	if _, err := d.DB.ExecContext(ctx, lockQuery); err != nil {
+		return err
+	}
+
+	return d.AddMissingColumns(ctx, d.lockTableName, []base.Column{{Name: "hostname", Type: "VARCHAR(255)"}})
 }
 
 // Lock acquires a distributed lock to prevent concurrent migrations.
@@ -126,6 +221,7 @@ func (d *Driver) Init(ctx context.Context) error {
 // 2. Checks if an active lock exists using SELECT with LIMIT
 // 3. If no lock exists, attempts INSERT
 // 4. Retries with exponential backoff until timeout or lock is acquired
+// 5. Renews expires_at every timeout/3 until Unlock (see base.Heartbeat)
 //
 // Exponential backoff starts at 50ms and doubles up to 1s maximum to reduce
 // database load during lock contention.
@@ -135,16 +231,25 @@ func (d *Driver) Lock(ctx context.Context, timeout time.Duration) error {
 	cfg := base.TableLockConfig{
 		CleanupQuery: fmt.Sprintf(
 			"DELETE FROM %s WHERE lock_key = $1 AND expires_at < now()",
-			d.Config.QuoteIdentifier(d.lockTableName),
+			d.QuoteTable(d.lockTableName),
 		),
 		CheckQuery: fmt.Sprintf(
 			"SELECT 1 FROM %s WHERE lock_key = $1 AND expires_at >= now() LIMIT 1",
-			d.Config.QuoteIdentifier(d.lockTableName),
+			d.QuoteTable(d.lockTableName),
 		),
 		InsertQuery: fmt.Sprintf(
-			"INSERT INTO %s (lock_key, expires_at, owner_id) VALUES ($1, $2, $3)",
-			d.Config.QuoteIdentifier(d.lockTableName),
+			"INSERT INTO %s (lock_key, expires_at, owner_id, hostname) VALUES ($1, $2, $3, $4)",
+			d.QuoteTable(d.lockTableName),
+		),
+		RenewQuery: fmt.Sprintf(
+			"UPDATE %s SET expires_at = $1 WHERE lock_key = $2 AND owner_id = $3",
+			d.QuoteTable(d.lockTableName),
+		),
+		HeldQuery: fmt.Sprintf(
+			"SELECT 1 FROM %s WHERE lock_key = $1 AND owner_id = $2 LIMIT 1",
+			d.QuoteTable(d.lockTableName),
 		),
+		Hostname: base.Hostname(),
 		ScanFunc: func(row *sql.Row) (bool, error) {
 			var exists int
 			err := row.Scan(&exists)
@@ -160,14 +265,18 @@ func (d *Driver) Lock(ctx context.Context, timeout time.Duration) error {
 		return fmt.Errorf("%w: failed to acquire lock '%s' for table '%s'",
 			queen.ErrLockTimeout, d.lockKey, d.lockTableName)
 	}
-	return err
+	if err != nil {
+		return err
+	}
 
+	d.heartbeat = base.StartHeartbeat(d.DB, cfg, d.lockKey, d.ownerID, timeout)
+	return nil
 }
 
 // Unlock releases the migration lock.
 //
 // This removes the lock record from the lock table, allowing other processes
-// to acquire the lock.
+// to acquire the lock. It also stops the lease renewal started by Lock.
 //
 // The unlock operation checks the owner_id to ensure only the process that
 // acquired the lock can release it. This prevents race conditions where an
@@ -177,9 +286,12 @@ func (d *Driver) Lock(ctx context.Context, timeout time.Duration) error {
 // already released, or belongs to another process. This prevents errors
 // during cleanup when locks expire or in error recovery scenarios.
 func (d *Driver) Unlock(ctx context.Context) error {
+	d.heartbeat.Stop()
+	d.heartbeat = nil
+
 	unlockQuery := fmt.Sprintf(
 		"DELETE FROM %s WHERE lock_key = $1 AND owner_id = $2",
-		d.Config.QuoteIdentifier(d.lockTableName),
+		d.QuoteTable(d.lockTableName),
 	)
 
 	// Execute DELETE - it's safe even if lock doesn't exist or belongs to another process
@@ -194,6 +306,37 @@ func (d *Driver) Unlock(ctx context.Context) error {
 	return err
 }
 
+// LockInfo returns the current holder of the migration lock, or nil if the
+// lock is free. It implements queen.LockInspector.
+func (d *Driver) LockInfo(ctx context.Context) (*queen.LockInfo, error) {
+	query := fmt.Sprintf(
+		"SELECT owner_id, hostname, acquired_at, expires_at FROM %s WHERE lock_key = $1",
+		d.QuoteTable(d.lockTableName),
+	)
+	return base.TableLockInfo(ctx, d.DB, query, d.lockKey)
+}
+
+// ForceUnlock removes the lock record regardless of its owner.
+func (d *Driver) ForceUnlock(ctx context.Context) error {
+	query := fmt.Sprintf(
+		"DELETE FROM %s WHERE lock_key = $1",
+		d.QuoteTable(d.lockTableName),
+	)
+	_, err := d.DB.ExecContext(ctx, query, d.lockKey)
+	return err
+}
+
+// LockLost returns a channel that is closed when the lock lease could not be
+// renewed. It implements queen.LeaseLocker.
+func (d *Driver) LockLost() <-chan struct{} {
+	return d.heartbeat.Lost()
+}
+
+// LockErr returns why the lock lease was lost.
+func (d *Driver) LockErr() error {
+	return d.heartbeat.Err()
+}
+
 // QuoteIdentifier quotes a SQL identifier (table name, column name) to prevent SQL injection.
 // In CockroachDB, identifiers are quoted with double quotes.
 //
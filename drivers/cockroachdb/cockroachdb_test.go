@@ -8,7 +8,6 @@ import (
 	"time"
 
 	"github.com/honeynil/queen"
-	"github.com/honeynil/queen/cli"
 	_ "github.com/jackc/pgx/v5/stdlib"
 )
 
@@ -70,7 +69,7 @@ func TestDriverCreation(t *testing.T) {
 		if driver.DB != db {
 			t.Error("driver.DB should be set")
 		}
-		if driver.TableName != cli.DefaultTableName {
+		if driver.TableName != "queen_migrations" {
 			t.Errorf("driver.TableName = %q; want %q", driver.TableName, "queen_migrations")
 		}
 		if driver.ownerID == "" {
@@ -107,6 +106,45 @@ func TestDriverCreation(t *testing.T) {
 			t.Error("expected different owner IDs for different driver instances")
 		}
 	})
+
+	t.Run("WithSchema qualifies all tables", func(t *testing.T) {
+		driver, err := New(db, WithSchema("app"))
+		if err != nil {
+			t.Fatalf("New() failed: %v", err)
+		}
+		if got, want := driver.QuoteTable(driver.TableName), `"app"."queen_migrations"`; got != want {
+			t.Errorf("QuoteTable() = %s; want %s", got, want)
+		}
+		if got, want := driver.QuoteTable(driver.lockTableName), `"app"."queen_migrations_lock"`; got != want {
+			t.Errorf("QuoteTable() = %s; want %s", got, want)
+		}
+	})
+
+	t.Run("lock key options", func(t *testing.T) {
+		driver, err := New(db)
+		if err != nil {
+			t.Fatalf("New() failed: %v", err)
+		}
+		if driver.lockKey != "migration_lock" {
+			t.Errorf("driver.lockKey = %q; want %q", driver.lockKey, "migration_lock")
+		}
+
+		driver, err = New(db, WithLockKey("billing"))
+		if err != nil {
+			t.Fatalf("New() failed: %v", err)
+		}
+		if driver.lockKey != "billing" {
+			t.Errorf("driver.lockKey = %q; want %q", driver.lockKey, "billing")
+		}
+
+		driver, err = NewWithTableName(db, "custom_migrations", WithLockID(42))
+		if err != nil {
+			t.Fatalf("NewWithTableName() failed: %v", err)
+		}
+		if driver.lockKey != "42" {
+			t.Errorf("driver.lockKey = %q; want %q", driver.lockKey, "42")
+		}
+	})
 }
 
 // setupTestDB creates a test database connection.
@@ -171,7 +209,7 @@ func TestInit(t *testing.T) {
 	if err != nil {
 		t.Fatalf("migrations table was not created: %v", err)
 	}
-	if tableName != cli.DefaultTableName {
+	if tableName != "queen_migrations" {
 		t.Errorf("table name = %q; want %q", tableName, "queen_migrations")
 	}
 
@@ -10,6 +10,7 @@ package mock
 import (
 	"context"
 	"database/sql"
+	"fmt"
 	"sort"
 	"sync"
 	"time"
@@ -23,7 +24,9 @@ type Driver struct {
 	mu        sync.Mutex
 	db        *sql.DB // In-memory SQLite database
 	applied   map[string]queen.Applied
+	audit     []queen.AuditEntry
 	locked    bool
+	lockedAt  time.Time
 	initErr   error
 	lockErr   error
 	recordErr error
@@ -109,16 +112,54 @@ func (d *Driver) Record(ctx context.Context, m *queen.Migration) error {
 		return d.recordErr
 	}
 
+	info, _ := queen.RecordInfoFromContext(ctx)
 	d.applied[m.Version] = queen.Applied{
-		Version:   m.Version,
-		Name:      m.Name,
-		AppliedAt: time.Now(),
-		Checksum:  m.Checksum(),
+		Version:      m.Version,
+		Name:         m.Name,
+		AppliedAt:    time.Now(),
+		Checksum:     m.Checksum(),
+		Duration:     info.Duration,
+		AppliedBy:    info.AppliedBy,
+		Hostname:     info.Hostname,
+		QueenVersion: info.QueenVersion,
+		BuildTag:     info.BuildTag,
 	}
 
 	return nil
 }
 
+// UpdateChecksum replaces the stored checksum of an applied migration.
+func (d *Driver) UpdateChecksum(ctx context.Context, version, checksum string) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	a, ok := d.applied[version]
+	if !ok {
+		return fmt.Errorf("migration %s is not applied", version)
+	}
+	a.Checksum = checksum
+	d.applied[version] = a
+	return nil
+}
+
+// RecordAudit appends an entry to the in-memory audit log.
+func (d *Driver) RecordAudit(ctx context.Context, e queen.AuditEntry) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	e.ExecutedAt = time.Now()
+	d.audit = append(d.audit, e)
+	return nil
+}
+
+// GetAudit returns all audit entries in the order they were recorded.
+func (d *Driver) GetAudit(ctx context.Context) ([]queen.AuditEntry, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return append([]queen.AuditEntry(nil), d.audit...), nil
+}
+
 // Remove removes a migration record.
 func (d *Driver) Remove(ctx context.Context, version string) error {
 	d.mu.Lock()
@@ -142,6 +183,7 @@ func (d *Driver) Lock(ctx context.Context, timeout time.Duration) error {
 	}
 
 	d.locked = true
+	d.lockedAt = time.Now()
 	return nil
 }
 
@@ -154,6 +196,22 @@ func (d *Driver) Unlock(ctx context.Context) error {
 	return nil
 }
 
+// LockInfo returns the lock holder, or nil if the lock is free.
+func (d *Driver) LockInfo(ctx context.Context) (*queen.LockInfo, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if !d.locked {
+		return nil, nil
+	}
+	return &queen.LockInfo{Key: "mock", Owner: "mock", AcquiredAt: d.lockedAt}, nil
+}
+
+// ForceUnlock releases the lock regardless of its owner.
+func (d *Driver) ForceUnlock(ctx context.Context) error {
+	return d.Unlock(ctx)
+}
+
 // Exec executes a function within a real SQLite transaction with the specified isolation level.
 //
 // This allows SQL migrations to be executed against the in-memory database.
@@ -175,6 +233,17 @@ func (d *Driver) Exec(ctx context.Context, isolationLevel sql.IsolationLevel, fn
 	return tx.Commit()
 }
 
+// ExecNoTx executes a function on a dedicated SQLite connection without a transaction.
+func (d *Driver) ExecNoTx(ctx context.Context, fn func(*sql.Conn) error) error {
+	conn, err := d.db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = conn.Close() }()
+
+	return fn(conn)
+}
+
 // Close closes the in-memory database connection.
 func (d *Driver) Close() error {
 	if d.db != nil {
@@ -205,7 +274,7 @@ func (d *Driver) HasVersion(version string) bool {
 	return exists
 }
 
-// Reset clears all applied migrations metadata (for testing).
+// Reset clears all applied migrations metadata and the audit log (for testing).
 //
 // Note: This only clears the migration tracking metadata. It does NOT
 // reset the in-memory database schema or data. To reset the database,
@@ -214,5 +283,6 @@ func (d *Driver) Reset() {
 	d.mu.Lock()
 	defer d.mu.Unlock()
 	d.applied = make(map[string]queen.Applied)
+	d.audit = nil
 	d.locked = false
 }
@@ -4,7 +4,9 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/honeynil/queen"
 	"github.com/honeynil/queen/drivers/mock"
@@ -223,6 +225,94 @@ func TestMockDriver_Reset(t *testing.T) {
 	}
 }
 
+func TestMockDriver_LockInfo(t *testing.T) {
+	driver := mock.New()
+	q := queen.New(driver)
+	ctx := context.Background()
+
+	info, err := q.LockInfo(ctx)
+	if err != nil {
+		t.Fatalf("LockInfo failed: %v", err)
+	}
+	if info != nil {
+		t.Fatalf("LockInfo = %+v, want nil for a free lock", info)
+	}
+
+	if err := driver.Lock(ctx, time.Second); err != nil {
+		t.Fatalf("Lock failed: %v", err)
+	}
+	info, err = q.LockInfo(ctx)
+	if err != nil {
+		t.Fatalf("LockInfo failed: %v", err)
+	}
+	if info == nil || info.AcquiredAt.IsZero() {
+		t.Fatalf("LockInfo = %+v, want holder with AcquiredAt", info)
+	}
+
+	if err := q.ForceUnlock(ctx); err != nil {
+		t.Fatalf("ForceUnlock failed: %v", err)
+	}
+	if driver.IsLocked() {
+		t.Error("lock still held after ForceUnlock")
+	}
+}
+
+func TestMockDriver_History(t *testing.T) {
+	driver := mock.New()
+	q := queen.New(driver)
+
+	q.MustAdd(queen.M{
+		Version:        "001",
+		Name:           "first",
+		ManualChecksum: "v1",
+		UpFunc:         func(ctx context.Context, tx *sql.Tx) error { return nil },
+		DownFunc:       func(ctx context.Context, tx *sql.Tx) error { return nil },
+	})
+	q.MustAdd(queen.M{
+		Version:        "002",
+		Name:           "broken",
+		ManualChecksum: "v1",
+		UpFunc:         func(ctx context.Context, tx *sql.Tx) error { return errors.New("boom") },
+	})
+
+	ctx := context.Background()
+
+	if err := q.Up(ctx); err == nil {
+		t.Fatal("Up should fail on the broken migration")
+	}
+	if err := q.Down(ctx, 1); err != nil {
+		t.Fatalf("Down failed: %v", err)
+	}
+
+	history, err := q.History(ctx)
+	if err != nil {
+		t.Fatalf("History failed: %v", err)
+	}
+
+	want := []struct {
+		version   string
+		direction string
+		success   bool
+	}{
+		{"001", "up", true},
+		{"002", "up", false},
+		{"001", "down", true},
+	}
+	if len(history) != len(want) {
+		t.Fatalf("History returned %d entries, want %d: %+v", len(history), len(want), history)
+	}
+	for i, w := range want {
+		e := history[i]
+		if e.Version != w.version || e.Direction != w.direction || e.Success != w.success {
+			t.Errorf("entry %d = %s %s success=%v, want %s %s success=%v",
+				i, e.Version, e.Direction, e.Success, w.version, w.direction, w.success)
+		}
+	}
+	if !strings.Contains(history[1].Error, "boom") {
+		t.Errorf("failed entry error = %q, want it to contain %q", history[1].Error, "boom")
+	}
+}
+
 func TestMockDriver_ErrorHandling(t *testing.T) {
 	driver := mock.New()
 	q := queen.New(driver)
@@ -24,7 +24,27 @@
 // the session ends or sp_releaseapplock() is called.
 //
 // The lock name is derived from the migrations table name to ensure different
-// migration tables use different locks.
+// migration tables use different locks. Names longer than 32 characters are
+// replaced with a hash, because sys.dm_tran_locks only shows the first 32
+// characters and LockInfo must find exactly this lock.
+//
+// # Schemas
+//
+// SQL Server can't change the default schema of a session or transaction:
+// unqualified names always resolve to the default schema of the database
+// user. WithSchema therefore requires the schema to be that default schema,
+// which is set up once per user:
+//
+//	ALTER USER app_migrator WITH DEFAULT_SCHEMA = app;
+//
+// The migrations tables are then created in the schema, and Init fails if
+// the connected user has a different default schema, so migrations never
+// create their objects somewhere else:
+//
+//	driver := mssql.New(db, mssql.WithSchema("app"))
+//	q.MustAdd(queen.M{Version: "001", Name: "users", UpSQL: "CREATE TABLE users (...)"})
+//
+// Members of the sysadmin role always use dbo and can't use WithSchema.
 //
 // # Compatibility
 //
@@ -37,6 +57,9 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"hash/fnv"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/honeynil/queen"
@@ -59,6 +82,18 @@ type Driver struct {
 	conn     *sql.Conn
 }
 
+// Option configures a MS SQL Server driver.
+type Option func(*Driver)
+
+// WithSchema keeps the migrations table and its companion tables in schema,
+// creating it if missing. schema must be the default schema of the database
+// user; see the package documentation.
+func WithSchema(schema string) Option {
+	return func(d *Driver) {
+		d.Schema = schema
+	}
+}
+
 // New creates a new MS SQL Server driver.
 //
 // The database connection should already be open and configured.
@@ -71,8 +106,8 @@ type Driver struct {
 //	    log.Fatal(err)
 //	}
 //	driver := mssql.New(db)
-func New(db *sql.DB) *Driver {
-	return NewWithTableName(db, "queen_migrations")
+func New(db *sql.DB, opts ...Option) *Driver {
+	return NewWithTableName(db, "queen_migrations", opts...)
 }
 
 // NewWithTableName creates a new MS SQL Server driver with a custom table name.
@@ -84,19 +119,50 @@ func New(db *sql.DB) *Driver {
 // Example:
 //
 //	driver := mssql.NewWithTableName(db, "my_custom_migrations")
-func NewWithTableName(db *sql.DB, tableName string) *Driver {
-	return &Driver{
+func NewWithTableName(db *sql.DB, tableName string, opts ...Option) *Driver {
+	d := &Driver{
 		Driver: base.Driver{
 			DB:        db,
 			TableName: tableName,
 			Config: base.Config{
-				Placeholder:     base.PlaceholderQuestion,
-				QuoteIdentifier: base.QuoteBrackets,
-				ParseTime:       nil, // SQL Server driver handles DATETIME2 parsing internally
+				Placeholder:      base.PlaceholderQuestion,
+				QuoteIdentifier:  base.QuoteBrackets,
+				ParseTime:        nil,  // SQL Server driver handles DATETIME2 parsing internally
+				TransactionalDDL: true, // SQL Server supports DDL inside transactions
+				HistoryTypes:     base.HistoryTypes{Integer: "BIGINT", Text: "NVARCHAR(255)"},
+				AddColumn:        addColumn,
 			},
 		},
-		lockName: "queen_lock_" + tableName,
 	}
+
+	for _, opt := range opts {
+		opt(d)
+	}
+
+	d.lockName = lockName(d.Schema, tableName)
+
+	return d
+}
+
+// maxLockNameLen is the number of characters of an application lock name
+// shown in the resource description of sys.dm_tran_locks.
+const maxLockNameLen = 32
+
+// lockName returns the application lock name of the migrations table.
+// Names that don't fit in maxLockNameLen are replaced with a fixed-length
+// FNV-1a hash, so no two tables share what LockInfo compares.
+func lockName(schema, tableName string) string {
+	name := "queen_lock_" + tableName
+	if schema != "" {
+		name = "queen_lock_" + schema + "." + tableName
+	}
+	if len(name) <= maxLockNameLen {
+		return name
+	}
+
+	h := fnv.New64a()
+	h.Write([]byte(name))
+	return fmt.Sprintf("queen_lock_%016x", h.Sum64())
 }
 
 // Init creates the migrations tracking table if it doesn't exist.
@@ -106,9 +172,33 @@ func NewWithTableName(db *sql.DB, tableName string) *Driver {
 //   - name: NVARCHAR(255) NOT NULL - human-readable migration name
 //   - applied_at: DATETIME2 - when the migration was applied
 //   - checksum: NVARCHAR(64) - hash of migration content for validation
+//   - duration_ms, applied_by, hostname, queen_version, build_tag - history
+//     columns, added to tables created by older versions automatically
+//
+// It also creates the audit table (see base.Driver.AuditTableName) and the
+// dirty marker table (see base.Driver.DirtyTableName).
+//
+// The schema set by WithSchema is created first if it doesn't exist, and
+// Init returns an error if it isn't the default schema of the database user.
 //
 // This method is idempotent and safe to call multiple times.
 func (d *Driver) Init(ctx context.Context) error {
+	if d.Schema != "" {
+		// CREATE SCHEMA must be the only statement in its batch, hence EXEC.
+		schemaQuery := fmt.Sprintf(`
+			IF SCHEMA_ID(N'%s') IS NULL
+				EXEC(N'CREATE SCHEMA %s')
+		`, quoteString(d.Schema), quoteString(d.Config.QuoteIdentifier(d.Schema)))
+
+		if _, err := d.DB.ExecContext(ctx, schemaQuery); err != nil {
+			return fmt.Errorf("failed to create schema '%s': %w", d.Schema, err)
+		}
+
+		if err := d.checkDefaultSchema(ctx); err != nil {
+			return err
+		}
+	}
+
 	// SQL Server doesn't support CREATE TABLE IF NOT EXISTS syntax,
 	// so we check for table existence first using OBJECT_ID.
 	query := fmt.Sprintf(`
@@ -121,12 +211,82 @@ func (d *Driver) Init(ctx context.Context) error {
 				checksum NVARCHAR(64) NOT NULL
 			)
 		END
-	`, d.TableName, d.Config.QuoteIdentifier(d.TableName))
+	`, quoteString(d.QuoteTable(d.TableName)), d.QuoteTable(d.TableName))
+
+	if _, err := d.DB.ExecContext(ctx, query); err != nil {
+		return err
+	}
+
+	if err := d.UpgradeSchema(ctx); err != nil {
+		return err
+	}
+
+	auditQuery := fmt.Sprintf(`
+		IF OBJECT_ID(N'%s', N'U') IS NULL
+		BEGIN
+			CREATE TABLE %s (
+				version NVARCHAR(255) NOT NULL,
+				name NVARCHAR(255) NOT NULL,
+				direction NVARCHAR(16) NOT NULL,
+				outcome NVARCHAR(16) NOT NULL,
+				error_message NVARCHAR(MAX) NOT NULL,
+				duration_ms BIGINT NOT NULL,
+				applied_by NVARCHAR(255) NOT NULL,
+				hostname NVARCHAR(255) NOT NULL,
+				queen_version NVARCHAR(255) NOT NULL,
+				build_tag NVARCHAR(255) NOT NULL,
+				executed_at DATETIME2 NOT NULL DEFAULT SYSUTCDATETIME()
+			)
+		END
+	`, quoteString(d.QuoteTable(d.AuditTableName())), d.QuoteTable(d.AuditTableName()))
 
-	_, err := d.DB.ExecContext(ctx, query)
+	if _, err := d.DB.ExecContext(ctx, auditQuery); err != nil {
+		return err
+	}
+
+	dirtyQuery := fmt.Sprintf(`
+		IF OBJECT_ID(N'%s', N'U') IS NULL
+		BEGIN
+			CREATE TABLE %s (
+				version NVARCHAR(255) PRIMARY KEY,
+				name NVARCHAR(255) NOT NULL,
+				direction NVARCHAR(16) NOT NULL,
+				started_at DATETIME2 NOT NULL DEFAULT SYSUTCDATETIME()
+			)
+		END
+	`, quoteString(d.QuoteTable(d.DirtyTableName())), d.QuoteTable(d.DirtyTableName()))
+
+	_, err := d.DB.ExecContext(ctx, dirtyQuery)
 	return err
 }
 
+// checkDefaultSchema verifies that unqualified names in migrations resolve
+// to the schema set by WithSchema.
+func (d *Driver) checkDefaultSchema(ctx context.Context) error {
+	var defaultSchema string
+	if err := d.DB.QueryRowContext(ctx, "SELECT SCHEMA_NAME()").Scan(&defaultSchema); err != nil {
+		return fmt.Errorf("failed to read the default schema: %w", err)
+	}
+
+	if !strings.EqualFold(defaultSchema, d.Schema) {
+		return fmt.Errorf("schema '%s' is not the default schema of the database user (%s); "+
+			"migrations would create their objects in %s (ALTER USER ... WITH DEFAULT_SCHEMA = %s)",
+			d.Schema, defaultSchema, defaultSchema, d.Config.QuoteIdentifier(d.Schema))
+	}
+	return nil
+}
+
+// quoteString escapes s for use inside an N'...' string literal.
+func quoteString(s string) string {
+	return strings.ReplaceAll(s, "'", "''")
+}
+
+// addColumn builds an ALTER TABLE statement in SQL Server syntax,
+// which has no COLUMN keyword after ADD.
+func addColumn(table, column, columnType string) string {
+	return fmt.Sprintf("ALTER TABLE %s ADD %s %s", table, column, columnType)
+}
+
 // Lock acquires an application lock to prevent concurrent migrations.
 //
 // SQL Server uses sp_getapplock() which creates an application lock bound to the
@@ -236,3 +396,68 @@ func (d *Driver) Unlock(ctx context.Context) error {
 
 	return nil
 }
+
+// LockInfo returns the session holding the application lock, or nil if the
+// lock is free. It implements queen.LockInspector.
+//
+// The holder is read from sys.dm_tran_locks, where the resource description
+// contains the lock name (see lockName). Owner is the session ID,
+// Host and AcquiredAt come from sys.dm_exec_sessions (the end of the
+// session's last request, i.e. sp_getapplock).
+func (d *Driver) LockInfo(ctx context.Context) (*queen.LockInfo, error) {
+	var (
+		session    int64
+		host       sql.NullString
+		acquiredAt sql.NullTime
+	)
+	query := `
+		SELECT TOP 1 l.request_session_id, s.host_name, s.last_request_end_time
+		FROM sys.dm_tran_locks l
+		JOIN sys.dm_exec_sessions s ON s.session_id = l.request_session_id
+		WHERE l.resource_type = 'APPLICATION'
+		  AND l.resource_database_id = DB_ID()
+		  AND l.request_owner_type = 'SESSION'
+		  AND l.request_status = 'GRANT'
+		  AND CHARINDEX(':[' + ? + ']', l.resource_description) > 0
+	`
+
+	err := d.DB.QueryRowContext(ctx, query, d.lockName).Scan(&session, &host, &acquiredAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read application lock '%s' for table '%s': %w",
+			d.lockName, d.TableName, err)
+	}
+
+	return &queen.LockInfo{
+		Key:        d.lockName,
+		Owner:      strconv.FormatInt(session, 10),
+		Host:       host.String,
+		AcquiredAt: acquiredAt.Time,
+	}, nil
+}
+
+// ForceUnlock kills the session holding the application lock, which releases it.
+// Requires the ALTER ANY CONNECTION permission.
+func (d *Driver) ForceUnlock(ctx context.Context) error {
+	info, err := d.LockInfo(ctx)
+	if err != nil || info == nil {
+		return err
+	}
+
+	// KILL doesn't accept parameters; the session ID is an integer from the server.
+	if _, err := d.DB.ExecContext(ctx, "KILL "+info.Owner); err != nil {
+		return fmt.Errorf("failed to kill holder of application lock '%s': %w", d.lockName, err)
+	}
+	return nil
+}
+
+// SplitStatements splits a migration script into batches on "GO" lines.
+//
+// GO is a client-side batch separator understood by sqlcmd and SSMS, not by
+// SQL Server itself. Statements like CREATE PROCEDURE must be the first in a
+// batch, so Queen executes each batch separately.
+func (d *Driver) SplitStatements(query string) ([]string, error) {
+	return base.SplitStatements(query, base.DialectMSSQL)
+}
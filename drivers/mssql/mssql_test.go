@@ -0,0 +1,30 @@
+package mssql
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLockName(t *testing.T) {
+	if got := lockName("", "queen_migrations"); got != "queen_lock_queen_migrations" {
+		t.Errorf("lockName() = %q, want the unhashed name for short names", got)
+	}
+	if got := lockName("app", "queen_migrations"); got != "queen_lock_app.queen_migrations" {
+		t.Errorf("lockName() = %q, want the unhashed name for short names", got)
+	}
+
+	// Both names share the first 32 characters
+	a := lockName("tenant_000000000001", "queen_migrations")
+	b := lockName("tenant_000000000002", "queen_migrations")
+	if a == b {
+		t.Errorf("lockName() = %q for two schemas, want different names", a)
+	}
+	for _, name := range []string{a, b} {
+		if len(name) > maxLockNameLen || !strings.HasPrefix(name, "queen_lock_") {
+			t.Errorf("lockName() = %q, want a queen_lock_ name of at most %d characters", name, maxLockNameLen)
+		}
+	}
+	if a != lockName("tenant_000000000001", "queen_migrations") {
+		t.Error("lockName() should be stable")
+	}
+}
@@ -42,6 +42,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/honeynil/queen"
@@ -100,6 +101,10 @@ func NewWithTableName(db *sql.DB, tableName string) *Driver {
 				// ParseTime is nil because MySQL driver handles TIMESTAMP parsing internally
 				// when parseTime=true is set in the DSN.
 				ParseTime: nil,
+				// DDL statements cause an implicit commit in MySQL, so migrations
+				// are recorded in a separate step after Exec.
+				TransactionalDDL: false,
+				HistoryTypes:     base.HistoryTypes{Integer: "BIGINT", Text: "VARCHAR(255)"},
 			},
 		},
 		lockName: "queen_lock_" + tableName,
@@ -113,6 +118,11 @@ func NewWithTableName(db *sql.DB, tableName string) *Driver {
 //   - name: VARCHAR(255) NOT NULL - human-readable migration name
 //   - applied_at: TIMESTAMP - when the migration was applied
 //   - checksum: VARCHAR(64) - hash of migration content for validation
+//   - duration_ms, applied_by, hostname, queen_version, build_tag - history
+//     columns, added to tables created by older versions automatically
+//
+// It also creates the audit table (see base.Driver.AuditTableName) and the
+// dirty marker table (see base.Driver.DirtyTableName).
 //
 // This method is idempotent and safe to call multiple times.
 func (d *Driver) Init(ctx context.Context) error {
@@ -125,7 +135,44 @@ func (d *Driver) Init(ctx context.Context) error {
 		) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci
 	`, d.Config.QuoteIdentifier(d.TableName))
 
-	_, err := d.DB.ExecContext(ctx, query)
+	if _, err := d.DB.ExecContext(ctx, query); err != nil {
+		return err
+	}
+
+	if err := d.UpgradeSchema(ctx); err != nil {
+		return err
+	}
+
+	auditQuery := fmt.Sprintf(`
+		CREATE TABLE IF NOT EXISTS %s (
+			version VARCHAR(255) NOT NULL,
+			name VARCHAR(255) NOT NULL,
+			direction VARCHAR(16) NOT NULL,
+			outcome VARCHAR(16) NOT NULL,
+			error_message TEXT NOT NULL,
+			duration_ms BIGINT NOT NULL,
+			applied_by VARCHAR(255) NOT NULL,
+			hostname VARCHAR(255) NOT NULL,
+			queen_version VARCHAR(255) NOT NULL,
+			build_tag VARCHAR(255) NOT NULL,
+			executed_at TIMESTAMP(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6)
+		) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci
+	`, d.Config.QuoteIdentifier(d.AuditTableName()))
+
+	if _, err := d.DB.ExecContext(ctx, auditQuery); err != nil {
+		return err
+	}
+
+	dirtyQuery := fmt.Sprintf(`
+		CREATE TABLE IF NOT EXISTS %s (
+			version VARCHAR(255) PRIMARY KEY,
+			name VARCHAR(255) NOT NULL,
+			direction VARCHAR(16) NOT NULL,
+			started_at TIMESTAMP(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6)
+		) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci
+	`, d.Config.QuoteIdentifier(d.DirtyTableName()))
+
+	_, err := d.DB.ExecContext(ctx, dirtyQuery)
 	return err
 }
 
@@ -216,3 +263,70 @@ func (d *Driver) Unlock(ctx context.Context) error {
 
 	return nil
 }
+
+// LockInfo returns the connection holding the named lock, or nil if the lock
+// is free. It implements queen.LockInspector.
+//
+// Owner is the connection ID returned by IS_USED_LOCK. Host and AcquiredAt
+// come from information_schema.PROCESSLIST; the lock connection stays idle
+// after GET_LOCK, so its TIME is the age of the lock.
+func (d *Driver) LockInfo(ctx context.Context) (*queen.LockInfo, error) {
+	id, err := d.lockHolder(ctx)
+	if err != nil || id == 0 {
+		return nil, err
+	}
+
+	info := &queen.LockInfo{Key: d.lockName, Owner: strconv.FormatInt(id, 10)}
+
+	var (
+		host    sql.NullString
+		seconds sql.NullInt64
+	)
+	err = d.DB.QueryRowContext(ctx,
+		"SELECT HOST, TIME FROM information_schema.PROCESSLIST WHERE ID = ?", id,
+	).Scan(&host, &seconds)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, fmt.Errorf("failed to read holder of named lock '%s': %w", d.lockName, err)
+	}
+
+	info.Host = host.String
+	if seconds.Valid {
+		info.AcquiredAt = time.Now().Add(-time.Duration(seconds.Int64) * time.Second)
+	}
+
+	return info, nil
+}
+
+// ForceUnlock kills the connection holding the named lock, which releases it.
+// Requires the CONNECTION_ADMIN (or SUPER) privilege for other users' connections.
+func (d *Driver) ForceUnlock(ctx context.Context) error {
+	id, err := d.lockHolder(ctx)
+	if err != nil || id == 0 {
+		return err
+	}
+
+	// KILL doesn't accept placeholders; id is an integer from the server.
+	if _, err := d.DB.ExecContext(ctx, fmt.Sprintf("KILL %d", id)); err != nil {
+		return fmt.Errorf("failed to kill holder of named lock '%s': %w", d.lockName, err)
+	}
+	return nil
+}
+
+// lockHolder returns the connection ID holding the named lock, or 0 if it is free.
+func (d *Driver) lockHolder(ctx context.Context) (int64, error) {
+	var id sql.NullInt64
+	if err := d.DB.QueryRowContext(ctx, "SELECT IS_USED_LOCK(?)", d.lockName).Scan(&id); err != nil {
+		return 0, fmt.Errorf("failed to check named lock '%s': %w", d.lockName, err)
+	}
+	return id.Int64, nil
+}
+
+// SplitStatements splits a migration script into individual statements.
+//
+// The go-sql-driver/mysql driver rejects multiple statements per Exec unless
+// the DSN sets multiStatements=true, so Queen executes them one by one.
+// Quoted strings, '#' comments and BEGIN...END bodies of triggers and
+// procedures are kept intact.
+func (d *Driver) SplitStatements(query string) ([]string, error) {
+	return base.SplitStatements(query, base.DialectMySQL)
+}
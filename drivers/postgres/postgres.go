@@ -5,6 +5,9 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"hash/fnv"
+	"strconv"
+	"sync"
 	"time"
 
 	"github.com/honeynil/queen"
@@ -14,35 +17,125 @@ import (
 // Driver implements the queen.Driver interface for PostgreSQL.
 type Driver struct {
 	base.Driver
-	lockID int64
-	lockConn *sql.Conn
+	lockPolling time.Duration
+	lockConn    *sql.Conn
+
+	lockIDMu      sync.Mutex // guards lockID and lockIDSet, resolved on first use
+	lockID        int64
+	lockIDSet     bool
+	scopedLockKey bool
+}
+
+// Option configures a PostgreSQL driver.
+type Option func(*Driver)
+
+// WithLockID sets the advisory lock key.
+//
+// Use it when several services must share (or must not share) a lock
+// regardless of where their migrations table lives.
+func WithLockID(id int64) Option {
+	return func(d *Driver) {
+		d.lockID = id
+		d.lockIDSet = true
+	}
+}
+
+// WithLockKey sets the advisory lock key to the 64-bit FNV-1a hash of key.
+func WithLockKey(key string) Option {
+	return func(d *Driver) {
+		d.lockID = hashLockKey(key)
+		d.lockIDSet = true
+	}
+}
+
+// WithScopedLockKey derives the advisory lock key from the current database,
+// schema and table name instead of the table name alone, so services sharing
+// a cluster only contend for the lock when they migrate the same table.
+// It is the default with WithSchema.
+//
+// Changing the key is not safe during a rolling deploy: instances using the
+// old key and instances using the new one don't exclude each other and may
+// migrate concurrently. Switch all instances at once, or use WithLockID or
+// WithLockKey with a fixed value.
+func WithScopedLockKey() Option {
+	return func(d *Driver) {
+		d.scopedLockKey = true
+	}
+}
+
+// WithLockPolling makes Lock poll pg_try_advisory_lock every interval instead
+// of blocking in pg_advisory_lock.
+//
+// Polling never leaves a waiting backend behind when the client gives up,
+// and the timeout error reports exactly how long Lock waited.
+func WithLockPolling(interval time.Duration) Option {
+	return func(d *Driver) {
+		d.lockPolling = interval
+	}
+}
+
+// WithSchema keeps the migrations table and its companion tables in schema,
+// creating it if missing. Migrations run with schema first in search_path,
+// so unqualified names in them refer to schema.
+func WithSchema(schema string) Option {
+	return func(d *Driver) {
+		d.Schema = schema
+	}
 }
 
 // New creates a new PostgreSQL driver.
 // The database connection should already be open and configured.
 // The default migrations table name is "queen_migrations".
-func New(db *sql.DB) *Driver {
-	return NewWithTableName(db, "queen_migrations")
+func New(db *sql.DB, opts ...Option) *Driver {
+	return NewWithTableName(db, "queen_migrations", opts...)
 }
 
 // NewWithTableName creates a new PostgreSQL driver with a custom table name.
-func NewWithTableName(db *sql.DB, tableName string) *Driver {
-	return &Driver{
+//
+// Unless WithLockID or WithLockKey is given, the advisory lock key is derived
+// from the table name, the same key earlier versions used, so old and new
+// binaries exclude each other during a rolling deploy. With WithSchema or
+// WithScopedLockKey it is a 64-bit hash of the current database, schema and
+// table name instead, resolved on first use.
+func NewWithTableName(db *sql.DB, tableName string, opts ...Option) *Driver {
+	d := &Driver{
 		Driver: base.Driver{
 			DB:        db,
 			TableName: tableName,
 			Config: base.Config{
-				Placeholder:     base.PlaceholderDollar,
-				QuoteIdentifier: base.QuoteDoubleQuotes,
-				ParseTime:       nil, // PostgreSQL supports TIMESTAMP natively
+				Placeholder:      base.PlaceholderDollar,
+				QuoteIdentifier:  base.QuoteDoubleQuotes,
+				ParseTime:        nil,  // PostgreSQL supports TIMESTAMP natively
+				TransactionalDDL: true, // DDL and tracking row commit atomically
+				HistoryTypes:     base.HistoryTypes{Integer: "BIGINT", Text: "VARCHAR(255)"},
+				SearchPath:       base.SearchPath,
 			},
 		},
-		lockID: hashTableName(tableName), // Unique lock ID based on table name
 	}
+
+	for _, opt := range opts {
+		opt(d)
+	}
+
+	if !d.lockIDSet && !d.scopedLockKey && d.Schema == "" {
+		d.lockID = hashTableName(tableName)
+		d.lockIDSet = true
+	}
+
+	return d
 }
 
-// Init creates the migrations tracking table if it doesn't exist.
+// Init creates the schema (see WithSchema) and the migrations tracking table
+// if they don't exist, adds missing history columns and creates the audit
+// (<table>_audit) and dirty marker (<table>_dirty) tables.
 func (d *Driver) Init(ctx context.Context) error {
+	if d.Schema != "" {
+		query := "CREATE SCHEMA IF NOT EXISTS " + d.Config.QuoteIdentifier(d.Schema)
+		if _, err := d.DB.ExecContext(ctx, query); err != nil {
+			return fmt.Errorf("failed to create schema '%s': %w", d.Schema, err)
+		}
+	}
+
 	query := fmt.Sprintf(`
 		CREATE TABLE IF NOT EXISTS %s (
 			version VARCHAR(255) PRIMARY KEY,
@@ -50,38 +143,134 @@ func (d *Driver) Init(ctx context.Context) error {
 			applied_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
 			checksum VARCHAR(64) NOT NULL
 		)
-	`, d.Config.QuoteIdentifier(d.TableName))
+	`, d.QuoteTable(d.TableName))
+
+	if _, err := d.DB.ExecContext(ctx, query); err != nil {
+		return err
+	}
+
+	if err := d.UpgradeSchema(ctx); err != nil {
+		return err
+	}
+
+	auditQuery := fmt.Sprintf(`
+		CREATE TABLE IF NOT EXISTS %s (
+			version VARCHAR(255) NOT NULL,
+			name VARCHAR(255) NOT NULL,
+			direction VARCHAR(16) NOT NULL,
+			outcome VARCHAR(16) NOT NULL,
+			error_message TEXT NOT NULL,
+			duration_ms BIGINT NOT NULL,
+			applied_by VARCHAR(255) NOT NULL,
+			hostname VARCHAR(255) NOT NULL,
+			queen_version VARCHAR(255) NOT NULL,
+			build_tag VARCHAR(255) NOT NULL,
+			executed_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+		)
+	`, d.QuoteTable(d.AuditTableName()))
+
+	if _, err := d.DB.ExecContext(ctx, auditQuery); err != nil {
+		return err
+	}
+
+	dirtyQuery := fmt.Sprintf(`
+		CREATE TABLE IF NOT EXISTS %s (
+			version VARCHAR(255) PRIMARY KEY,
+			name VARCHAR(255) NOT NULL,
+			direction VARCHAR(16) NOT NULL,
+			started_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+		)
+	`, d.QuoteTable(d.DirtyTableName()))
 
-	_, err := d.DB.ExecContext(ctx, query)
+	_, err := d.DB.ExecContext(ctx, dirtyQuery)
 	return err
 }
 
 // Lock acquires an advisory lock to prevent concurrent migrations.
 // PostgreSQL advisory locks are automatically released when the connection closes
 // or when explicitly unlocked.
+//
+// By default Lock blocks in pg_advisory_lock until timeout. With
+// WithLockPolling it retries pg_try_advisory_lock instead.
 func (d *Driver) Lock(ctx context.Context, timeout time.Duration) error {
 	conn, err := d.DB.Conn(ctx)
 	if err != nil {
 		return err
 	}
 
+	id, err := d.resolveLockID(ctx, conn)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+
+	if d.lockPolling > 0 {
+		err = d.pollLock(ctx, conn, id, timeout)
+	} else {
+		err = d.waitLock(ctx, conn, id, timeout)
+	}
+	if err != nil {
+		conn.Close()
+		return err
+	}
+
+	d.lockConn = conn
+	return nil
+}
+
+// waitLock blocks in pg_advisory_lock until the lock is acquired or timeout expires.
+func (d *Driver) waitLock(ctx context.Context, conn *sql.Conn, id int64, timeout time.Duration) error {
+	start := time.Now()
+
 	lockCtx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
-	_, err = conn.ExecContext(lockCtx, "SELECT pg_advisory_lock($1)", d.lockID)
+	_, err := conn.ExecContext(lockCtx, "SELECT pg_advisory_lock($1)", id)
 	if err != nil {
-		conn.Close()
 		if lockCtx.Err() == context.DeadlineExceeded {
-			return fmt.Errorf("%w: failed to acquire advisory lock '%d' for table '%s'",
-				queen.ErrLockTimeout, d.lockID, d.TableName)
+			return d.lockTimeoutError(id, time.Since(start))
 		}
 		return err
 	}
-
-	d.lockConn = conn
 	return nil
 }
 
+// pollLock retries pg_try_advisory_lock every lockPolling until the lock is
+// acquired or timeout expires.
+func (d *Driver) pollLock(ctx context.Context, conn *sql.Conn, id int64, timeout time.Duration) error {
+	start := time.Now()
+	deadline := start.Add(timeout)
+
+	for {
+		var acquired bool
+		err := conn.QueryRowContext(ctx, "SELECT pg_try_advisory_lock($1)", id).Scan(&acquired)
+		if err != nil {
+			return err
+		}
+		if acquired {
+			return nil
+		}
+
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			return d.lockTimeoutError(id, time.Since(start))
+		}
+
+		timer := time.NewTimer(min(d.lockPolling, remaining))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+func (d *Driver) lockTimeoutError(id int64, waited time.Duration) error {
+	return fmt.Errorf("%w: failed to acquire advisory lock '%d' for table '%s' after %s",
+		queen.ErrLockTimeout, id, d.TableName, waited.Round(time.Millisecond))
+}
+
 // Unlock releases the advisory lock.
 func (d *Driver) Unlock(ctx context.Context) error {
 	if d.lockConn == nil {
@@ -92,16 +281,126 @@ func (d *Driver) Unlock(ctx context.Context) error {
 		d.lockConn = nil
 	}()
 
-	_, err := d.lockConn.ExecContext(ctx, "SELECT pg_advisory_unlock($1)", d.lockID)
+	// The key was resolved by Lock
+	id, err := d.resolveLockID(ctx, d.lockConn)
 	if err != nil {
+		return err
+	}
+
+	if _, err := d.lockConn.ExecContext(ctx, "SELECT pg_advisory_unlock($1)", id); err != nil {
 		return fmt.Errorf("failed to release advisory lock '%d' for table '%s': %w",
-			d.lockID, d.TableName, err)
+			id, d.TableName, err)
 	}
 	return nil
 }
 
-// hashTableName creates a unique int64 hash from the table name for advisory locks.
-// This ensures different migration tables use different locks.
+// advisoryLockHolderQuery selects the session holding the advisory lock $1.
+// A bigint advisory key is stored in pg_locks as classid (high 32 bits)
+// and objid (low 32 bits) with objsubid = 1.
+const advisoryLockHolderQuery = `
+	FROM pg_locks l
+	JOIN pg_stat_activity a ON a.pid = l.pid
+	WHERE l.locktype = 'advisory'
+	  AND l.granted
+	  AND l.objsubid = 1
+	  AND ((l.classid::bigint << 32) | l.objid::bigint) = $1
+`
+
+// LockInfo returns the session holding the advisory lock, or nil if the lock
+// is free. It implements queen.LockInspector.
+//
+// Owner is the backend PID of the holder. AcquiredAt is the last state change
+// of its session, which is when the lock was taken because the lock
+// connection runs nothing else afterwards.
+func (d *Driver) LockInfo(ctx context.Context) (*queen.LockInfo, error) {
+	query := `SELECT a.pid, COALESCE(a.client_hostname, host(a.client_addr), 'local'), a.state_change` +
+		advisoryLockHolderQuery + ` LIMIT 1`
+
+	id, err := d.resolveLockID(ctx, d.DB)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		pid         int64
+		host        string
+		stateChange sql.NullTime
+	)
+	err = d.DB.QueryRowContext(ctx, query, id).Scan(&pid, &host, &stateChange)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read advisory lock '%d' for table '%s': %w",
+			id, d.TableName, err)
+	}
+
+	return &queen.LockInfo{
+		Key:        strconv.FormatInt(id, 10),
+		Owner:      strconv.FormatInt(pid, 10),
+		Host:       host,
+		AcquiredAt: stateChange.Time,
+	}, nil
+}
+
+// ForceUnlock terminates the session holding the advisory lock, which releases it.
+// Requires superuser or membership in pg_signal_backend.
+func (d *Driver) ForceUnlock(ctx context.Context) error {
+	id, err := d.resolveLockID(ctx, d.DB)
+	if err != nil {
+		return err
+	}
+
+	query := `SELECT pg_terminate_backend(l.pid)` + advisoryLockHolderQuery
+
+	if _, err := d.DB.ExecContext(ctx, query, id); err != nil {
+		return fmt.Errorf("failed to terminate holder of advisory lock '%d' for table '%s': %w",
+			id, d.TableName, err)
+	}
+	return nil
+}
+
+// queryRower is implemented by *sql.DB and *sql.Conn.
+type queryRower interface {
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// resolveLockID returns the advisory lock key. The scoped key (see
+// WithScopedLockKey) is derived from the current database, schema (WithSchema
+// or current_schema()) and table name on first use; the legacy key and
+// explicit keys set by WithLockID or WithLockKey are known up front.
+func (d *Driver) resolveLockID(ctx context.Context, db queryRower) (int64, error) {
+	d.lockIDMu.Lock()
+	defer d.lockIDMu.Unlock()
+
+	if d.lockIDSet {
+		return d.lockID, nil
+	}
+
+	var database, schema sql.NullString
+	err := db.QueryRowContext(ctx, "SELECT current_database(), current_schema()").Scan(&database, &schema)
+	if err != nil {
+		return 0, fmt.Errorf("failed to resolve advisory lock key for table '%s': %w", d.TableName, err)
+	}
+
+	if d.Schema != "" {
+		schema.String = d.Schema
+	}
+
+	d.lockID = hashLockKey(database.String + "\x00" + schema.String + "\x00" + d.TableName)
+	d.lockIDSet = true
+	return d.lockID, nil
+}
+
+// hashLockKey maps key to an advisory lock ID using 64-bit FNV-1a.
+func hashLockKey(key string) int64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(key))
+	return int64(h.Sum64())
+}
+
+// hashTableName is the advisory lock key of earlier versions, kept as the
+// default so that upgraded and old binaries take the same lock.
 func hashTableName(name string) int64 {
 	var hash int64
 	for i, c := range name {
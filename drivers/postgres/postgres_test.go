@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -699,3 +700,222 @@ func TestLock_ContextCancellation(t *testing.T) {
 		t.Errorf("expected context.Canceled, got %v", err)
 	}
 }
+
+func TestLockID(t *testing.T) {
+	t.Parallel()
+
+	const resolveQuery = "SELECT current_database(), current_schema()"
+
+	t.Run("default key is the legacy table name hash", func(t *testing.T) {
+		t.Parallel()
+
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		if err != nil {
+			t.Fatalf("failed to open sqlmock: %v", err)
+		}
+		defer db.Close()
+
+		// Same key as earlier versions, so old and new binaries exclude each other
+		want := hashTableName("queen_migrations")
+		mock.ExpectExec("SELECT pg_advisory_lock($1)").WithArgs(want).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("SELECT pg_advisory_unlock($1)").WithArgs(want).WillReturnResult(sqlmock.NewResult(0, 0))
+
+		driver := New(db)
+		ctx := context.Background()
+		if err := driver.Lock(ctx, time.Second); err != nil {
+			t.Fatalf("Lock() error = %v", err)
+		}
+		if err := driver.Unlock(ctx); err != nil {
+			t.Fatalf("Unlock() error = %v", err)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	t.Run("WithScopedLockKey hashes database, schema and table", func(t *testing.T) {
+		t.Parallel()
+
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		if err != nil {
+			t.Fatalf("failed to open sqlmock: %v", err)
+		}
+		defer db.Close()
+
+		want := hashLockKey("app\x00public\x00queen_migrations")
+		mock.ExpectQuery(resolveQuery).
+			WillReturnRows(sqlmock.NewRows([]string{"current_database", "current_schema"}).AddRow("app", "public"))
+		mock.ExpectExec("SELECT pg_advisory_lock($1)").WithArgs(want).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("SELECT pg_advisory_unlock($1)").WithArgs(want).WillReturnResult(sqlmock.NewResult(0, 0))
+
+		driver := New(db, WithScopedLockKey())
+		ctx := context.Background()
+		if err := driver.Lock(ctx, time.Second); err != nil {
+			t.Fatalf("Lock() error = %v", err)
+		}
+		if err := driver.Unlock(ctx); err != nil {
+			t.Fatalf("Unlock() error = %v", err)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	t.Run("WithSchema replaces current schema", func(t *testing.T) {
+		t.Parallel()
+
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		if err != nil {
+			t.Fatalf("failed to open sqlmock: %v", err)
+		}
+		defer db.Close()
+
+		mock.ExpectQuery(resolveQuery).
+			WillReturnRows(sqlmock.NewRows([]string{"current_database", "current_schema"}).AddRow("app", "public"))
+
+		driver := New(db, WithSchema("billing"))
+		id, err := driver.resolveLockID(context.Background(), db)
+		if err != nil {
+			t.Fatalf("resolveLockID() error = %v", err)
+		}
+		if want := hashLockKey("app\x00billing\x00queen_migrations"); id != want {
+			t.Errorf("lockID = %d; want %d", id, want)
+		}
+		if got, want := driver.QuoteTable(driver.TableName), `"billing"."queen_migrations"`; got != want {
+			t.Errorf("QuoteTable() = %s; want %s", got, want)
+		}
+	})
+
+	t.Run("concurrent first use resolves once", func(t *testing.T) {
+		t.Parallel()
+
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		if err != nil {
+			t.Fatalf("failed to open sqlmock: %v", err)
+		}
+		defer db.Close()
+
+		mock.ExpectQuery(resolveQuery).
+			WillReturnRows(sqlmock.NewRows([]string{"current_database", "current_schema"}).AddRow("app", "public"))
+
+		driver := New(db, WithScopedLockKey())
+		want := hashLockKey("app\x00public\x00queen_migrations")
+
+		var wg sync.WaitGroup
+		for range 4 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if id, err := driver.resolveLockID(context.Background(), db); err != nil || id != want {
+					t.Errorf("resolveLockID() = %d, %v; want %d", id, err, want)
+				}
+			}()
+		}
+		wg.Wait()
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	t.Run("keys differ across schemas and tables", func(t *testing.T) {
+		t.Parallel()
+
+		keys := map[int64]string{}
+		for _, parts := range []string{
+			"app\x00public\x00queen_migrations",
+			"app\x00billing\x00queen_migrations",
+			"other\x00public\x00queen_migrations",
+			"app\x00public\x00queen_migrations_v2",
+		} {
+			id := hashLockKey(parts)
+			if prev, ok := keys[id]; ok {
+				t.Errorf("hashLockKey(%q) collides with %q", parts, prev)
+			}
+			keys[id] = parts
+		}
+	})
+
+	t.Run("explicit keys skip resolution", func(t *testing.T) {
+		t.Parallel()
+
+		for name, tt := range map[string]struct {
+			opt  Option
+			want int64
+		}{
+			"WithLockID":  {WithLockID(42), 42},
+			"WithLockKey": {WithLockKey("billing"), hashLockKey("billing")},
+		} {
+			t.Run(name, func(t *testing.T) {
+				db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+				if err != nil {
+					t.Fatalf("failed to open sqlmock: %v", err)
+				}
+				defer db.Close()
+
+				mock.ExpectExec("SELECT pg_advisory_lock($1)").WithArgs(tt.want).WillReturnResult(sqlmock.NewResult(0, 0))
+
+				driver := New(db, tt.opt)
+				if err := driver.Lock(context.Background(), time.Second); err != nil {
+					t.Fatalf("Lock() error = %v", err)
+				}
+				if err := mock.ExpectationsWereMet(); err != nil {
+					t.Error(err)
+				}
+			})
+		}
+	})
+}
+
+func TestLockPolling(t *testing.T) {
+	t.Parallel()
+
+	tryRows := func(acquired bool) *sqlmock.Rows {
+		return sqlmock.NewRows([]string{"pg_try_advisory_lock"}).AddRow(acquired)
+	}
+
+	t.Run("retries until acquired", func(t *testing.T) {
+		t.Parallel()
+
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		if err != nil {
+			t.Fatalf("failed to open sqlmock: %v", err)
+		}
+		defer db.Close()
+
+		mock.ExpectQuery("SELECT pg_try_advisory_lock($1)").WithArgs(int64(7)).WillReturnRows(tryRows(false))
+		mock.ExpectQuery("SELECT pg_try_advisory_lock($1)").WithArgs(int64(7)).WillReturnRows(tryRows(true))
+
+		driver := New(db, WithLockID(7), WithLockPolling(5*time.Millisecond))
+		if err := driver.Lock(context.Background(), time.Second); err != nil {
+			t.Fatalf("Lock() error = %v", err)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	t.Run("timeout reports wait time", func(t *testing.T) {
+		t.Parallel()
+
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		if err != nil {
+			t.Fatalf("failed to open sqlmock: %v", err)
+		}
+		defer db.Close()
+
+		mock.MatchExpectationsInOrder(false)
+		for range 20 {
+			mock.ExpectQuery("SELECT pg_try_advisory_lock($1)").WithArgs(int64(7)).WillReturnRows(tryRows(false))
+		}
+
+		driver := New(db, WithLockID(7), WithLockPolling(10*time.Millisecond))
+		err = driver.Lock(context.Background(), 30*time.Millisecond)
+		if !errors.Is(err, queen.ErrLockTimeout) {
+			t.Fatalf("Lock() error = %v, want ErrLockTimeout", err)
+		}
+		if !strings.Contains(err.Error(), "after ") {
+			t.Errorf("Lock() error = %q, want wait time", err)
+		}
+	})
+}
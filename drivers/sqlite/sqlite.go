@@ -105,9 +105,11 @@ func NewWithTableName(db *sql.DB, tableName string) *Driver {
 			DB:        db,
 			TableName: tableName,
 			Config: base.Config{
-				Placeholder:     base.PlaceholderQuestion,
-				QuoteIdentifier: base.QuoteDoubleQuotes,
-				ParseTime:       base.ParseTimeISO8601, // SQLite stores timestamps as TEXT
+				Placeholder:      base.PlaceholderQuestion,
+				QuoteIdentifier:  base.QuoteDoubleQuotes,
+				ParseTime:        base.ParseTimeISO8601, // SQLite stores timestamps as TEXT
+				TransactionalDDL: true,                  // SQLite DDL is fully transactional
+				HistoryTypes:     base.HistoryTypes{Integer: "INTEGER", Text: "TEXT"},
 			},
 		},
 	}
@@ -120,6 +122,11 @@ func NewWithTableName(db *sql.DB, tableName string) *Driver {
 //   - name: TEXT NOT NULL - human-readable migration name
 //   - applied_at: TEXT - ISO8601 timestamp when migration was applied
 //   - checksum: TEXT - hash of migration content for validation
+//   - duration_ms, applied_by, hostname, queen_version, build_tag - history
+//     columns, added to tables created by older versions automatically
+//
+// It also creates the audit table (see base.Driver.AuditTableName) and the
+// dirty marker table (see base.Driver.DirtyTableName).
 //
 // This method is idempotent and safe to call multiple times.
 //
@@ -135,7 +142,45 @@ func (d *Driver) Init(ctx context.Context) error {
 		) WITHOUT ROWID
 	`, d.Config.QuoteIdentifier(d.TableName))
 
-	_, err := d.DB.ExecContext(ctx, query)
+	if _, err := d.DB.ExecContext(ctx, query); err != nil {
+		return err
+	}
+
+	if err := d.UpgradeSchema(ctx); err != nil {
+		return err
+	}
+
+	// executed_at keeps milliseconds so entries written within a second stay ordered.
+	auditQuery := fmt.Sprintf(`
+		CREATE TABLE IF NOT EXISTS %s (
+			version TEXT NOT NULL,
+			name TEXT NOT NULL,
+			direction TEXT NOT NULL,
+			outcome TEXT NOT NULL,
+			error_message TEXT NOT NULL,
+			duration_ms INTEGER NOT NULL,
+			applied_by TEXT NOT NULL,
+			hostname TEXT NOT NULL,
+			queen_version TEXT NOT NULL,
+			build_tag TEXT NOT NULL,
+			executed_at TEXT NOT NULL DEFAULT (strftime('%%Y-%%m-%%d %%H:%%M:%%f', 'now'))
+		)
+	`, d.Config.QuoteIdentifier(d.AuditTableName()))
+
+	if _, err := d.DB.ExecContext(ctx, auditQuery); err != nil {
+		return err
+	}
+
+	dirtyQuery := fmt.Sprintf(`
+		CREATE TABLE IF NOT EXISTS %s (
+			version TEXT PRIMARY KEY,
+			name TEXT NOT NULL,
+			direction TEXT NOT NULL,
+			started_at TEXT NOT NULL DEFAULT (datetime('now'))
+		) WITHOUT ROWID
+	`, d.Config.QuoteIdentifier(d.DirtyTableName()))
+
+	_, err := d.DB.ExecContext(ctx, dirtyQuery)
 	return err
 }
 
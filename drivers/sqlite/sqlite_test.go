@@ -260,6 +260,166 @@ func TestRecordAndGetApplied(t *testing.T) {
 	}
 }
 
+func TestHistoryColumns(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	driver := New(db)
+	ctx := context.Background()
+
+	// Table created by an older version without history columns
+	_, err := db.ExecContext(ctx, `
+		CREATE TABLE queen_migrations (
+			version TEXT PRIMARY KEY,
+			name TEXT NOT NULL,
+			applied_at TEXT NOT NULL DEFAULT (datetime('now')),
+			checksum TEXT NOT NULL
+		) WITHOUT ROWID
+	`)
+	if err != nil {
+		t.Fatalf("failed to create legacy table: %v", err)
+	}
+	_, err = db.ExecContext(ctx,
+		"INSERT INTO queen_migrations (version, name, applied_at, checksum) VALUES ('001', 'legacy', '2020-01-01 00:00:00', 'abc')")
+	if err != nil {
+		t.Fatalf("failed to insert legacy row: %v", err)
+	}
+
+	// Init upgrades the schema, and is idempotent
+	for i := 0; i < 2; i++ {
+		if err := driver.Init(ctx); err != nil {
+			t.Fatalf("Init() #%d failed: %v", i+1, err)
+		}
+	}
+
+	missing, err := driver.MissingHistoryColumns(ctx)
+	if err != nil {
+		t.Fatalf("MissingHistoryColumns() failed: %v", err)
+	}
+	if len(missing) != 0 {
+		t.Errorf("missing columns after Init: %v", missing)
+	}
+
+	info := queen.RecordInfo{
+		Duration:     1500 * time.Millisecond,
+		AppliedBy:    "deployer",
+		Hostname:     "ci-runner-1",
+		QueenVersion: "v1.2.3",
+		BuildTag:     "abc123",
+	}
+	m := &queen.Migration{Version: "002", Name: "create_posts", UpSQL: "CREATE TABLE posts (id INTEGER)"}
+	if err := driver.Record(queen.ContextWithRecordInfo(ctx, info), m); err != nil {
+		t.Fatalf("Record() failed: %v", err)
+	}
+
+	applied, err := driver.GetApplied(ctx)
+	if err != nil {
+		t.Fatalf("GetApplied() failed: %v", err)
+	}
+	if len(applied) != 2 {
+		t.Fatalf("expected 2 migrations, got %d", len(applied))
+	}
+
+	// Legacy rows have empty history fields
+	legacy := applied[0]
+	if legacy.Version != "001" || legacy.AppliedBy != "" || legacy.Duration != 0 {
+		t.Errorf("legacy row = %+v; want empty history fields", legacy)
+	}
+
+	got := applied[1]
+	if got.Duration != info.Duration {
+		t.Errorf("Duration = %v; want %v", got.Duration, info.Duration)
+	}
+	if got.AppliedBy != info.AppliedBy || got.Hostname != info.Hostname ||
+		got.QueenVersion != info.QueenVersion || got.BuildTag != info.BuildTag {
+		t.Errorf("history = %+v; want %+v", got, info)
+	}
+}
+
+func TestAudit(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	driver := New(db)
+	ctx := context.Background()
+
+	if err := driver.Init(ctx); err != nil {
+		t.Fatalf("Init() failed: %v", err)
+	}
+
+	entries := []queen.AuditEntry{
+		{Version: "001", Name: "create_users", Direction: "up", Success: true, Duration: 20 * time.Millisecond, AppliedBy: "alice"},
+		{Version: "002", Name: "add_email", Direction: "up", Success: false, Error: "syntax error", AppliedBy: "alice"},
+		{Version: "001", Name: "create_users", Direction: "down", Success: true, AppliedBy: "bob", Hostname: "laptop"},
+	}
+	for _, e := range entries {
+		if err := driver.RecordAudit(ctx, e); err != nil {
+			t.Fatalf("RecordAudit() failed: %v", err)
+		}
+	}
+
+	got, err := driver.GetAudit(ctx)
+	if err != nil {
+		t.Fatalf("GetAudit() failed: %v", err)
+	}
+	if len(got) != len(entries) {
+		t.Fatalf("expected %d entries, got %d", len(entries), len(got))
+	}
+
+	for i, want := range entries {
+		g := got[i]
+		if g.ExecutedAt.IsZero() {
+			t.Errorf("entry %d: ExecutedAt is zero", i)
+		}
+		g.ExecutedAt = time.Time{}
+		if g != want {
+			t.Errorf("entry %d = %+v; want %+v", i, g, want)
+		}
+	}
+}
+
+func TestDirty(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	driver := New(db)
+	ctx := context.Background()
+
+	if err := driver.Init(ctx); err != nil {
+		t.Fatalf("Init() failed: %v", err)
+	}
+
+	m := &queen.Migration{Version: "001", Name: "create_users"}
+	if err := driver.SetDirty(ctx, m, "up"); err != nil {
+		t.Fatalf("SetDirty() failed: %v", err)
+	}
+
+	dirty, err := driver.GetDirty(ctx)
+	if err != nil {
+		t.Fatalf("GetDirty() failed: %v", err)
+	}
+	if len(dirty) != 1 {
+		t.Fatalf("expected 1 dirty migration, got %d", len(dirty))
+	}
+	if dirty[0].Version != "001" || dirty[0].Name != "create_users" || dirty[0].Direction != "up" {
+		t.Errorf("dirty = %+v", dirty[0])
+	}
+	if dirty[0].StartedAt.IsZero() {
+		t.Error("StartedAt is zero")
+	}
+
+	if err := driver.ClearDirty(ctx, "001"); err != nil {
+		t.Fatalf("ClearDirty() failed: %v", err)
+	}
+	dirty, err = driver.GetDirty(ctx)
+	if err != nil {
+		t.Fatalf("GetDirty() failed: %v", err)
+	}
+	if len(dirty) != 0 {
+		t.Errorf("expected no dirty migrations, got %d", len(dirty))
+	}
+}
+
 func TestRemove(t *testing.T) {
 	db, cleanup := setupTestDB(t)
 	defer cleanup()
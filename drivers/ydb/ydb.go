@@ -58,6 +58,7 @@ type Driver struct {
 	lockTableName string
 	lockKey       string
 	ownerID       string
+	heartbeat     *base.Heartbeat
 }
 
 // New creates a new YDB driver.
@@ -109,6 +110,9 @@ func NewWithTableName(db *sql.DB, tableName string) (*Driver, error) {
 				QuoteIdentifier: base.QuoteBackticks,
 				// YDB supports TIMESTAMP natively
 				ParseTime: nil,
+				// YDB runs schema queries outside data transactions; record after Exec.
+				TransactionalDDL: false,
+				HistoryTypes:     base.HistoryTypes{Integer: "Int64", Text: "Utf8"},
 			},
 		},
 		lockTableName: tableName + "_lock",
@@ -124,12 +128,18 @@ func NewWithTableName(db *sql.DB, tableName string) (*Driver, error) {
 //   - name:        Utf8 NOT NULL - human-readable migration name
 //   - applied_at:  Timestamp NOT NULL - when the migration was applied
 //   - checksum:    Utf8 NOT NULL - hash of migration content for validation
+//   - duration_ms, applied_by, hostname, queen_version, build_tag - history
+//     columns, added to tables created by older versions automatically
+//
+// It also creates the audit table (see base.Driver.AuditTableName) and the
+// dirty marker table (see base.Driver.DirtyTableName).
 //
 // The lock table schema:
 //   - lock_key:    Utf8 PRIMARY KEY - lock identifier
 //   - acquired_at: Timestamp - when the lock was acquired
 //   - expires_at:  Timestamp NOT NULL - when the lock expires
 //   - owner_id:    Utf8 NOT NULL - unique owner identifier
+//   - hostname:    Utf8 - host of the owner (added automatically)
 //   - TTL: automatic cleanup of expired locks
 //
 // YDB note: YDB requires explicit PRIMARY KEY specification for all tables.
@@ -137,6 +147,8 @@ func NewWithTableName(db *sql.DB, tableName string) (*Driver, error) {
 //
 // This method is idempotent and safe to call multiple times.
 func (d *Driver) Init(ctx context.Context) error {
+	dataCtx := ctx
+
 	// YDB requires SchemeQueryMode for DDL operations (CREATE TABLE, etc.)
 	ctx = ydb.WithQueryMode(ctx, ydb.SchemeQueryMode)
 
@@ -155,6 +167,55 @@ func (d *Driver) Init(ctx context.Context) error {
 		return fmt.Errorf("failed to create migrations table: %w", err)
 	}
 
+	// Add history columns missing from tables created by older versions.
+	// Columns are read in data mode, ALTER TABLE runs in scheme mode.
+	missing, err := d.MissingHistoryColumns(dataCtx)
+	if err != nil {
+		return err
+	}
+	for _, c := range missing {
+		if _, err := d.DB.ExecContext(ctx, d.AddColumnQuery(c)); err != nil {
+			return fmt.Errorf("failed to add column '%s' to migrations table: %w", c.Name, err)
+		}
+	}
+
+	// Create append-only audit table
+	auditQuery := fmt.Sprintf(`
+		CREATE TABLE IF NOT EXISTS %s (
+			version       Utf8,
+			name          Utf8,
+			direction     Utf8,
+			outcome       Utf8,
+			error_message Utf8,
+			duration_ms   Int64,
+			applied_by    Utf8,
+			hostname      Utf8,
+			queen_version Utf8,
+			build_tag     Utf8,
+			executed_at   Timestamp,
+			PRIMARY KEY (executed_at, version, direction)
+		)
+	`, d.Config.QuoteIdentifier(d.AuditTableName()))
+
+	if _, err := d.DB.ExecContext(ctx, auditQuery); err != nil {
+		return fmt.Errorf("failed to create audit table: %w", err)
+	}
+
+	// Create dirty marker table for interrupted migrations
+	dirtyQuery := fmt.Sprintf(`
+		CREATE TABLE IF NOT EXISTS %s (
+			version     Utf8,
+			name        Utf8,
+			direction   Utf8,
+			started_at  Timestamp,
+			PRIMARY KEY (version)
+		)
+	`, d.Config.QuoteIdentifier(d.DirtyTableName()))
+
+	if _, err := d.DB.ExecContext(ctx, dirtyQuery); err != nil {
+		return fmt.Errorf("failed to create dirty table: %w", err)
+	}
+
 	// Create lock table with TTL for automatic cleanup
 	lockQuery := fmt.Sprintf(`
 		CREATE TABLE IF NOT EXISTS %s (
@@ -162,6 +223,7 @@ func (d *Driver) Init(ctx context.Context) error {
 			acquired_at Timestamp,
 			expires_at  Timestamp NOT NULL,
 			owner_id    Utf8 NOT NULL,
+			hostname    Utf8,
 			PRIMARY KEY (lock_key)
 		)
 		WITH (
@@ -173,6 +235,16 @@ func (d *Driver) Init(ctx context.Context) error {
 		return fmt.Errorf("failed to create lock table: %w", err)
 	}
 
+	missing, err = d.MissingColumns(dataCtx, d.lockTableName, []base.Column{{Name: "hostname", Type: "Utf8"}})
+	if err != nil {
+		return err
+	}
+	for _, c := range missing {
+		if _, err := d.DB.ExecContext(ctx, d.AddColumnQueryFor(d.lockTableName, c)); err != nil {
+			return fmt.Errorf("failed to add column '%s' to lock table: %w", c.Name, err)
+		}
+	}
+
 	return nil
 }
 
@@ -187,6 +259,7 @@ func (d *Driver) Init(ctx context.Context) error {
 // 2. Checks if an active lock exists using SELECT
 // 3. If no lock exists, attempts INSERT
 // 4. Retries with exponential backoff until timeout or lock is acquired
+// 5. Renews expires_at every timeout/3 until Unlock (see base.Heartbeat)
 //
 // YDB uses optimistic concurrency control, so INSERT conflicts are handled
 // gracefully by retrying with backoff.
@@ -206,9 +279,18 @@ func (d *Driver) Lock(ctx context.Context, timeout time.Duration) error {
 			d.Config.QuoteIdentifier(d.lockTableName),
 		),
 		InsertQuery: fmt.Sprintf(
-			"INSERT INTO %s (lock_key, acquired_at, expires_at, owner_id) VALUES ($1, CurrentUtcTimestamp(), $2, $3)",
+			"INSERT INTO %s (lock_key, acquired_at, expires_at, owner_id, hostname) VALUES ($1, CurrentUtcTimestamp(), $2, $3, $4)",
+			d.Config.QuoteIdentifier(d.lockTableName),
+		),
+		RenewQuery: fmt.Sprintf(
+			"UPDATE %s SET expires_at = $1 WHERE lock_key = $2 AND owner_id = $3",
 			d.Config.QuoteIdentifier(d.lockTableName),
 		),
+		HeldQuery: fmt.Sprintf(
+			"SELECT 1 FROM %s WHERE lock_key = $1 AND owner_id = $2 LIMIT 1",
+			d.Config.QuoteIdentifier(d.lockTableName),
+		),
+		Hostname: base.Hostname(),
 		ScanFunc: func(row *sql.Row) (bool, error) {
 			var exists int
 			err := row.Scan(&exists)
@@ -224,13 +306,18 @@ func (d *Driver) Lock(ctx context.Context, timeout time.Duration) error {
 		return fmt.Errorf("%w: failed to acquire lock '%s' for table '%s'",
 			queen.ErrLockTimeout, d.lockKey, d.lockTableName)
 	}
-	return err
+	if err != nil {
+		return err
+	}
+
+	d.heartbeat = base.StartHeartbeat(d.DB, cfg, d.lockKey, d.ownerID, timeout)
+	return nil
 }
 
 // Unlock releases the migration lock.
 //
 // This removes the lock record from the lock table, allowing other processes
-// to acquire the lock.
+// to acquire the lock. It also stops the lease renewal started by Lock.
 //
 // The unlock operation checks the owner_id to ensure only the process that
 // acquired the lock can release it. This prevents race conditions where an
@@ -240,6 +327,9 @@ func (d *Driver) Lock(ctx context.Context, timeout time.Duration) error {
 // already released, or belongs to another process. This prevents errors
 // during cleanup when locks expire via TTL or in error recovery scenarios.
 func (d *Driver) Unlock(ctx context.Context) error {
+	d.heartbeat.Stop()
+	d.heartbeat = nil
+
 	unlockQuery := fmt.Sprintf(
 		"DELETE FROM %s WHERE lock_key = $1 AND owner_id = $2",
 		d.Config.QuoteIdentifier(d.lockTableName),
@@ -258,6 +348,37 @@ func (d *Driver) Unlock(ctx context.Context) error {
 	return nil
 }
 
+// LockInfo returns the current holder of the migration lock, or nil if the
+// lock is free. It implements queen.LockInspector.
+func (d *Driver) LockInfo(ctx context.Context) (*queen.LockInfo, error) {
+	query := fmt.Sprintf(
+		"SELECT owner_id, hostname, acquired_at, expires_at FROM %s WHERE lock_key = $1",
+		d.Config.QuoteIdentifier(d.lockTableName),
+	)
+	return base.TableLockInfo(ctx, d.DB, query, d.lockKey)
+}
+
+// ForceUnlock removes the lock record regardless of its owner.
+func (d *Driver) ForceUnlock(ctx context.Context) error {
+	query := fmt.Sprintf(
+		"DELETE FROM %s WHERE lock_key = $1",
+		d.Config.QuoteIdentifier(d.lockTableName),
+	)
+	_, err := d.DB.ExecContext(ctx, query, d.lockKey)
+	return err
+}
+
+// LockLost returns a channel that is closed when the lock lease could not be
+// renewed. It implements queen.LeaseLocker.
+func (d *Driver) LockLost() <-chan struct{} {
+	return d.heartbeat.Lost()
+}
+
+// LockErr returns why the lock lease was lost.
+func (d *Driver) LockErr() error {
+	return d.heartbeat.Err()
+}
+
 // Record marks a migration as applied in the database.
 // YDB-specific implementation that includes applied_at timestamp.
 //
@@ -267,12 +388,53 @@ func (d *Driver) Unlock(ctx context.Context) error {
 // in the SQL query instead of relying on a column DEFAULT.
 func (d *Driver) Record(ctx context.Context, m *queen.Migration) error {
 	query := fmt.Sprintf(`
-		INSERT INTO %s (version, name, applied_at, checksum)
-		VALUES ($1, $2, CurrentUtcTimestamp(), $3)
+		INSERT INTO %s (version, name, applied_at, checksum,
+			duration_ms, applied_by, hostname, queen_version, build_tag)
+		VALUES ($1, $2, CurrentUtcTimestamp(), $3, $4, $5, $6, $7, $8)
 	`,
 		d.Config.QuoteIdentifier(d.TableName),
 	)
 
-	_, err := d.DB.ExecContext(ctx, query, m.Version, m.Name, m.Checksum())
+	args := append([]any{m.Version, m.Name, m.Checksum()}, base.RecordArgs(ctx)...)
+	_, err := d.DB.ExecContext(ctx, query, args...)
+	return err
+}
+
+// RecordAudit appends an entry to the audit table.
+//
+// YDB tables have no column defaults, so executed_at is set explicitly.
+func (d *Driver) RecordAudit(ctx context.Context, e queen.AuditEntry) error {
+	query := fmt.Sprintf(`
+		INSERT INTO %s (version, name, direction, outcome, error_message,
+			duration_ms, applied_by, hostname, queen_version, build_tag, executed_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, CurrentUtcTimestamp())
+	`,
+		d.Config.QuoteIdentifier(d.AuditTableName()),
+	)
+
+	_, err := d.DB.ExecContext(ctx, query, base.AuditArgs(e)...)
+	return err
+}
+
+// SetDirty records that a migration is being executed in the given direction.
+//
+// YDB tables have no column defaults, so started_at is set explicitly.
+func (d *Driver) SetDirty(ctx context.Context, m *queen.Migration, direction string) error {
+	query := fmt.Sprintf(`
+		INSERT INTO %s (version, name, direction, started_at)
+		VALUES ($1, $2, $3, CurrentUtcTimestamp())
+	`,
+		d.Config.QuoteIdentifier(d.DirtyTableName()),
+	)
+
+	_, err := d.DB.ExecContext(ctx, query, m.Version, m.Name, direction)
 	return err
 }
+
+// SplitStatements splits a migration script into individual statements.
+//
+// YDB does not allow mixing schema and data statements in one query,
+// so Queen runs multi-statement migrations statement by statement.
+func (d *Driver) SplitStatements(query string) ([]string, error) {
+	return base.SplitStatements(query, base.DialectYDB)
+}
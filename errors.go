@@ -17,6 +17,16 @@ var (
 	ErrNameTooLong          = errors.New("migration name exceeds 63 characters")
 	ErrInvalidMigrationName = errors.New("invalid migration name")
 	ErrAlreadyApplied       = errors.New("migration already applied")
+	ErrNoTxUnsupported      = errors.New("driver does not support non-transactional migrations")
+	ErrOutOfOrder           = errors.New("out-of-order migration")
+	ErrOrphanedMigration    = errors.New("applied migration is not registered")
+	ErrAuditUnsupported     = errors.New("driver does not support audit log")
+	ErrNotApplied           = errors.New("migration not applied")
+	ErrDirty                = errors.New("database is dirty")
+	ErrLockLost             = errors.New("migration lock lost")
+	ErrLockInfoUnsupported  = errors.New("driver does not support lock inspection")
+	ErrTargetsFailed        = errors.New("migration failed on some targets")
+	ErrInvalidSource        = errors.New("invalid migration source")
 )
 
 // MigrationError wraps an error with migration context.
@@ -30,6 +40,11 @@ type MigrationError struct {
 	Operation string // Operation being performed: "up", "down", "validate"
 	Driver    string // Database driver name (e.g., "postgres", "mysql", "sqlite")
 	Cause     error  // The underlying error that occurred
+
+	// StatementIndex and Statement identify the failing statement (1-based)
+	// when the driver splits SQL into statements. Zero and empty otherwise.
+	StatementIndex int
+	Statement      string
 }
 
 func (e *MigrationError) Error() string {
@@ -49,12 +64,47 @@ func (e *MigrationError) Unwrap() error {
 }
 
 // newMigrationError creates a new MigrationError with full context.
+// The failing statement is copied from a wrapped StatementError, if any.
 func newMigrationError(version, name, operation, driver string, err error) error {
-	return &MigrationError{
+	me := &MigrationError{
 		Version:   version,
 		Name:      name,
 		Operation: operation,
 		Driver:    driver,
 		Cause:     err,
 	}
+
+	var stmtErr *StatementError
+	if errors.As(err, &stmtErr) {
+		me.StatementIndex = stmtErr.Index
+		me.Statement = stmtErr.Statement
+	}
+
+	return me
+}
+
+// StatementError reports which statement of a multi-statement migration failed.
+//
+// It is returned for SQL migrations executed by drivers that implement
+// StatementSplitter, and is available through MigrationError.Cause.
+type StatementError struct {
+	Index     int    // 1-based index of the failing statement
+	Total     int    // Number of statements in the migration
+	Statement string // Text of the failing statement
+	Cause     error  // The underlying database error
+}
+
+// maxStatementLen limits the statement text included in error messages.
+const maxStatementLen = 200
+
+func (e *StatementError) Error() string {
+	stmt := e.Statement
+	if len(stmt) > maxStatementLen {
+		stmt = stmt[:maxStatementLen] + "..."
+	}
+	return fmt.Sprintf("statement %d of %d failed: %v\n  %s", e.Index, e.Total, e.Cause, stmt)
+}
+
+func (e *StatementError) Unwrap() error {
+	return e.Cause
 }
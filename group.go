@@ -0,0 +1,67 @@
+package queen
+
+import (
+	"fmt"
+	"strings"
+
+	naturalsort "github.com/honeynil/queen/internal/sort"
+)
+
+// groupSeparator separates the group from the version in tracked versions,
+// e.g. "billing/001".
+const groupSeparator = "/"
+
+// GroupOf returns the group of a tracked version ("billing" for "billing/001"),
+// or "" for ungrouped migrations.
+func GroupOf(version string) string {
+	group, _ := splitGroup(version)
+	return group
+}
+
+// splitGroup splits a tracked version into its group and the version within the group.
+func splitGroup(version string) (group, local string) {
+	if group, local, ok := strings.Cut(version, groupSeparator); ok {
+		return group, local
+	}
+	return "", version
+}
+
+// qualifyGroup moves Migration.Group into the version, so that migrations of
+// different groups can share a version ("billing/001" and "auth/001").
+// A version that is already qualified sets the group instead.
+func qualifyGroup(m *Migration) error {
+	group, local := splitGroup(m.Version)
+
+	switch {
+	case m.Group == "":
+		m.Group = group
+	case group != "":
+		return fmt.Errorf("%w: version %s already has a group, Group %s is redundant",
+			ErrInvalidMigration, m.Version, m.Group)
+	default:
+		m.Version = m.Group + groupSeparator + local
+	}
+
+	if m.Group != "" && !IsValidMigrationName(m.Group) {
+		return fmt.Errorf("%w: invalid group %q", ErrInvalidMigration, m.Group)
+	}
+	return nil
+}
+
+// compareVersions orders tracked versions by group, then by natural sort
+// within the group. Ungrouped versions come first.
+func compareVersions(a, b string) int {
+	groupA, localA := splitGroup(a)
+	groupB, localB := splitGroup(b)
+
+	if c := strings.Compare(groupA, groupB); c != 0 {
+		return c
+	}
+	return naturalsort.Compare(localA, localB)
+}
+
+// inGroup reports whether version belongs to the group selected by Config.Group.
+// Every version matches when no group is selected.
+func (q *Queen) inGroup(version string) bool {
+	return q.config.Group == "" || GroupOf(version) == q.config.Group
+}
@@ -0,0 +1,185 @@
+package queen
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func groupMigration(group, version, name string) M {
+	m := noopMigration(version, name)
+	m.Group = group
+	return m
+}
+
+// newGroupQueen registers billing/1, billing/2, billing/10 and auth/1, auth/2.
+func newGroupQueen(driver Driver, config *Config) *Queen {
+	if config == nil {
+		config = DefaultConfig()
+	}
+	q := NewWithConfig(driver, config)
+	q.MustAdd(groupMigration("billing", "10", "invoice_index"))
+	q.MustAdd(groupMigration("billing", "1", "create_invoices"))
+	q.MustAdd(groupMigration("auth", "1", "create_sessions"))
+	q.MustAdd(groupMigration("billing", "2", "create_payments"))
+	q.MustAdd(noopMigration("auth/2", "create_tokens"))
+	return q
+}
+
+func TestGroupOf(t *testing.T) {
+	tests := map[string]string{
+		"001":         "",
+		"billing/001": "billing",
+		"auth/v1_2":   "auth",
+	}
+	for version, want := range tests {
+		if got := GroupOf(version); got != want {
+			t.Errorf("GroupOf(%q) = %q, want %q", version, got, want)
+		}
+	}
+}
+
+func TestCompareVersions(t *testing.T) {
+	versions := []string{"billing/10", "2", "auth/2", "billing/2", "10", "auth/1"}
+	slices.SortFunc(versions, compareVersions)
+
+	want := []string{"2", "10", "auth/1", "auth/2", "billing/2", "billing/10"}
+	if !slices.Equal(versions, want) {
+		t.Errorf("sorted = %v, want %v", versions, want)
+	}
+}
+
+func TestGroups(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Add qualifies versions per group", func(t *testing.T) {
+		t.Parallel()
+
+		q := New(newMemDriver())
+		q.MustAdd(groupMigration("billing", "001", "a"))
+
+		if err := q.Add(groupMigration("auth", "001", "a")); err != nil {
+			t.Errorf("same version in another group: %v", err)
+		}
+		if err := q.Add(groupMigration("billing", "001", "b")); !errors.Is(err, ErrVersionConflict) {
+			t.Errorf("duplicate version in group: got %v, want ErrVersionConflict", err)
+		}
+		if err := q.Add(M{Version: "billing/001", Name: "c", UpSQL: "SELECT 1"}); !errors.Is(err, ErrVersionConflict) {
+			t.Errorf("qualified duplicate: got %v, want ErrVersionConflict", err)
+		}
+		if err := q.Add(groupMigration("billing", "auth/002", "d")); !errors.Is(err, ErrInvalidMigration) {
+			t.Errorf("group twice: got %v, want ErrInvalidMigration", err)
+		}
+		if err := q.Add(groupMigration("Billing", "002", "e")); !errors.Is(err, ErrInvalidMigration) {
+			t.Errorf("invalid group: got %v, want ErrInvalidMigration", err)
+		}
+
+		if got := q.migrations[1]; got.Version != "auth/001" || got.Group != "auth" {
+			t.Errorf("registered = %s (group %q), want auth/001 (group auth)", got.Version, got.Group)
+		}
+	})
+
+	t.Run("Up applies each group in order", func(t *testing.T) {
+		t.Parallel()
+
+		q := newGroupQueen(newMemDriver(), nil)
+		var order []string
+		for _, m := range q.getPending() {
+			order = append(order, m.Version)
+		}
+
+		want := []string{"auth/1", "auth/2", "billing/1", "billing/2", "billing/10"}
+		if !slices.Equal(order, want) {
+			t.Errorf("pending = %v, want %v", order, want)
+		}
+	})
+
+	t.Run("out-of-order is checked within a group", func(t *testing.T) {
+		t.Parallel()
+
+		driver := newMemDriver()
+		driver.applied["billing/10"] = Applied{Version: "billing/10", Checksum: "v1"}
+
+		// auth/1 is older than billing/10 by natural sort, but in another group
+		q := newGroupQueen(driver, &Config{OutOfOrder: OutOfOrderError, Group: "auth"})
+		if err := q.Up(context.Background()); err != nil {
+			t.Fatalf("Up(auth) error = %v", err)
+		}
+
+		q = newGroupQueen(driver, &Config{OutOfOrder: OutOfOrderError})
+		err := q.Up(context.Background())
+		if !errors.Is(err, ErrOutOfOrder) {
+			t.Fatalf("Up() error = %v, want ErrOutOfOrder", err)
+		}
+		if want := "billing/1, billing/2 older than latest applied version billing/10"; !strings.Contains(err.Error(), want) {
+			t.Errorf("Up() error = %q, want %q", err, want)
+		}
+	})
+
+	t.Run("Config.Group limits Up, Down and Status", func(t *testing.T) {
+		t.Parallel()
+
+		driver := newMemDriver()
+		driver.applied["auth/1"] = Applied{Version: "auth/1", Checksum: "v1"}
+		driver.applied["auth/0"] = Applied{Version: "auth/0", Checksum: "v1"}
+		driver.applied["billing/0"] = Applied{Version: "billing/0", Checksum: "v1"}
+		ctx := context.Background()
+
+		q := newGroupQueen(driver, &Config{Group: "billing"})
+		if err := q.Up(ctx); err != nil {
+			t.Fatalf("Up() error = %v", err)
+		}
+		want := []string{"auth/0", "auth/1", "billing/0", "billing/1", "billing/2", "billing/10"}
+		if got := driver.appliedVersions(); !slices.Equal(got, want) {
+			t.Errorf("applied = %v, want %v", got, want)
+		}
+
+		statuses, err := q.Status(ctx)
+		if err != nil {
+			t.Fatalf("Status() error = %v", err)
+		}
+		var versions []string
+		for _, s := range statuses {
+			if s.Group != "billing" {
+				t.Errorf("status %s has group %q, want billing", s.Version, s.Group)
+			}
+			versions = append(versions, s.Version)
+		}
+		if want := []string{"billing/10", "billing/1", "billing/2", "billing/0"}; !slices.Equal(versions, want) {
+			t.Errorf("statuses = %v, want %v", versions, want)
+		}
+
+		q = newGroupQueen(driver, &Config{Group: "auth"})
+		if err := q.Down(ctx, 1); err != nil {
+			t.Fatalf("Down() error = %v", err)
+		}
+		if _, ok := driver.applied["auth/1"]; ok {
+			t.Error("Down(auth) should roll back auth/1")
+		}
+		if _, ok := driver.applied["billing/10"]; !ok {
+			t.Error("Down(auth) should not touch billing")
+		}
+	})
+
+	t.Run("MigrateTo and Baseline stay in the version's group", func(t *testing.T) {
+		t.Parallel()
+
+		driver := newMemDriver()
+		ctx := context.Background()
+
+		q := newGroupQueen(driver, nil)
+		if _, err := q.Baseline(ctx, "auth/1"); err != nil {
+			t.Fatalf("Baseline() error = %v", err)
+		}
+		if err := q.MigrateTo(ctx, "billing/2"); err != nil {
+			t.Fatalf("MigrateTo() error = %v", err)
+		}
+
+		want := []string{"auth/1", "billing/1", "billing/2"}
+		if got := driver.appliedVersions(); !slices.Equal(got, want) {
+			t.Errorf("applied = %v, want %v", got, want)
+		}
+	})
+}
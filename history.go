@@ -0,0 +1,119 @@
+package queen
+
+import (
+	"context"
+	"os"
+	"os/user"
+	"runtime/debug"
+	"sync"
+	"time"
+)
+
+// modulePath is the import path of this module, used to detect its version.
+const modulePath = "github.com/honeynil/queen"
+
+// RecordInfo carries execution metadata stored in the migration history table.
+//
+// Queen attaches it to the context passed to Driver.Record and TxRecorder.RecordTx.
+// Drivers that keep history columns read it with RecordInfoFromContext;
+// drivers that don't simply ignore it.
+type RecordInfo struct {
+	// Duration is how long the migration took to execute.
+	Duration time.Duration
+
+	// AppliedBy identifies who applied the migration.
+	// Config.AppliedBy if set, otherwise the OS user.
+	AppliedBy string
+
+	// Hostname is the host that applied the migration.
+	Hostname string
+
+	// QueenVersion is the version of the Queen module that applied the migration.
+	QueenVersion string
+
+	// BuildTag is the application-supplied build identifier (Config.BuildTag).
+	BuildTag string
+}
+
+type recordInfoKey struct{}
+
+// ContextWithRecordInfo returns a copy of ctx carrying info.
+func ContextWithRecordInfo(ctx context.Context, info RecordInfo) context.Context {
+	return context.WithValue(ctx, recordInfoKey{}, info)
+}
+
+// RecordInfoFromContext returns the RecordInfo attached by Queen, if any.
+func RecordInfoFromContext(ctx context.Context) (RecordInfo, bool) {
+	info, ok := ctx.Value(recordInfoKey{}).(RecordInfo)
+	return info, ok
+}
+
+// recordInfo builds the RecordInfo for a migration that started at start.
+func (q *Queen) recordInfo(start time.Time) RecordInfo {
+	env := loadEnvironment()
+
+	info := RecordInfo{
+		Duration:     time.Since(start),
+		AppliedBy:    q.config.AppliedBy,
+		Hostname:     env.hostname,
+		QueenVersion: env.queenVersion,
+		BuildTag:     q.config.BuildTag,
+	}
+	if info.AppliedBy == "" {
+		info.AppliedBy = env.username
+	}
+
+	return info
+}
+
+// environment holds process-wide values that don't change between migrations.
+type environment struct {
+	username     string
+	hostname     string
+	queenVersion string
+}
+
+var (
+	envOnce sync.Once
+	env     environment
+)
+
+// loadEnvironment detects the OS user, hostname and Queen version once.
+func loadEnvironment() environment {
+	envOnce.Do(func() {
+		if u, err := user.Current(); err == nil {
+			env.username = u.Username
+		} else if name := os.Getenv("USER"); name != "" {
+			env.username = name
+		} else {
+			env.username = os.Getenv("USERNAME")
+		}
+
+		env.hostname, _ = os.Hostname()
+		env.queenVersion = detectQueenVersion()
+	})
+	return env
+}
+
+// detectQueenVersion returns the Queen module version from the build info,
+// e.g. "v0.5.0", or "(devel)" when built from a local checkout.
+func detectQueenVersion() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return ""
+	}
+
+	if info.Main.Path == modulePath {
+		return info.Main.Version
+	}
+	for _, dep := range info.Deps {
+		if dep.Path == modulePath {
+			if dep.Replace != nil {
+				return dep.Replace.Version
+			}
+			return dep.Version
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,87 @@
+package queen
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+// infoDriver captures the RecordInfo passed to Record.
+type infoDriver struct {
+	*memDriver
+	infos []RecordInfo
+}
+
+func (d *infoDriver) Record(ctx context.Context, m *Migration) error {
+	info, ok := RecordInfoFromContext(ctx)
+	if !ok {
+		return errors.New("record info missing from context")
+	}
+	d.infos = append(d.infos, info)
+	return d.memDriver.Record(ctx, m)
+}
+
+func TestRecordInfo(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	t.Run("config values are recorded", func(t *testing.T) {
+		driver := &infoDriver{memDriver: newMemDriver()}
+		q := NewWithConfig(driver, &Config{
+			TableName: "queen_migrations",
+			AppliedBy: "deploy-bot",
+			BuildTag:  "git-abc123",
+		})
+		q.MustAdd(noopMigration("001", "first"))
+		q.MustAdd(noopMigration("002", "second"))
+
+		if err := q.Up(ctx); err != nil {
+			t.Fatalf("Up() error = %v", err)
+		}
+
+		if len(driver.infos) != 2 {
+			t.Fatalf("Record called %d times; want 2", len(driver.infos))
+		}
+		for _, info := range driver.infos {
+			if info.AppliedBy != "deploy-bot" {
+				t.Errorf("AppliedBy = %q; want %q", info.AppliedBy, "deploy-bot")
+			}
+			if info.BuildTag != "git-abc123" {
+				t.Errorf("BuildTag = %q; want %q", info.BuildTag, "git-abc123")
+			}
+			if info.Duration < 0 {
+				t.Errorf("Duration = %v; want >= 0", info.Duration)
+			}
+		}
+	})
+
+	t.Run("applied by defaults to OS user", func(t *testing.T) {
+		driver := &infoDriver{memDriver: newMemDriver()}
+		q := New(driver)
+		q.MustAdd(noopMigration("001", "first"))
+
+		if err := q.Up(ctx); err != nil {
+			t.Fatalf("Up() error = %v", err)
+		}
+
+		if got, want := driver.infos[0].AppliedBy, loadEnvironment().username; got != want {
+			t.Errorf("AppliedBy = %q; want %q", got, want)
+		}
+	})
+
+	t.Run("no info outside of Queen", func(t *testing.T) {
+		if _, ok := RecordInfoFromContext(ctx); ok {
+			t.Error("RecordInfoFromContext() ok = true for a plain context")
+		}
+	})
+}
+
+func TestHistoryUnsupported(t *testing.T) {
+	t.Parallel()
+
+	q := New(newMemDriver())
+	if _, err := q.History(context.Background()); !errors.Is(err, ErrAuditUnsupported) {
+		t.Errorf("History() error = %v; want ErrAuditUnsupported", err)
+	}
+}
@@ -0,0 +1,148 @@
+package queen
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// HookEvent describes a migration or a run passed to lifecycle hooks.
+type HookEvent struct {
+	// Migration is the migration being executed. Nil for run hooks.
+	Migration *Migration
+
+	// Direction is "up" or "down". Run hooks of MigrateTo receive "goto".
+	Direction string
+
+	// Tx is the migration transaction. Nil for run hooks, OnError hooks
+	// and NoTransaction migrations.
+	Tx *sql.Tx
+
+	// Duration is the time elapsed since the migration or run started.
+	// Zero in before hooks.
+	Duration time.Duration
+
+	// Err is the failure. Only set for OnError and AfterRun hooks.
+	Err error
+}
+
+// Hook is a lifecycle callback registered with WithBeforeMigration,
+// WithAfterMigration, WithBeforeRun, WithAfterRun or WithOnError.
+//
+// Returning an error from a before or after hook aborts the migration or run.
+// Errors returned by OnError hooks are ignored.
+type Hook func(ctx context.Context, e HookEvent) error
+
+// hooks holds registered lifecycle hooks in registration order.
+type hooks struct {
+	beforeMigration []Hook
+	afterMigration  []Hook
+	beforeRun       []Hook
+	afterRun        []Hook
+	onError         []Hook
+}
+
+// WithBeforeMigration registers a hook called before each migration is executed.
+//
+// The hook runs inside the migration transaction (e.Tx), so returning an error
+// vetoes the migration and rolls back anything the hook did.
+func WithBeforeMigration(h Hook) Option {
+	return func(q *Queen) {
+		q.hooks.beforeMigration = append(q.hooks.beforeMigration, h)
+	}
+}
+
+// WithAfterMigration registers a hook called after each migration is executed.
+//
+// The hook runs inside the migration transaction before it commits, so work
+// done through e.Tx (e.g. refreshing a materialized view) is atomic with the
+// migration. Returning an error rolls the migration back.
+//
+//	q := queen.New(driver, queen.WithAfterMigration(
+//	    func(ctx context.Context, e queen.HookEvent) error {
+//	        _, err := e.Tx.ExecContext(ctx, "REFRESH MATERIALIZED VIEW user_stats")
+//	        return err
+//	    }))
+func WithAfterMigration(h Hook) Option {
+	return func(q *Queen) {
+		q.hooks.afterMigration = append(q.hooks.afterMigration, h)
+	}
+}
+
+// WithBeforeRun registers a hook called once before Up, UpSteps, Down, Reset or
+// MigrateTo executes migrations. It runs after the lock is acquired and is
+// skipped when there is nothing to do. Returning an error cancels the run.
+func WithBeforeRun(h Hook) Option {
+	return func(q *Queen) {
+		q.hooks.beforeRun = append(q.hooks.beforeRun, h)
+	}
+}
+
+// WithAfterRun registers a hook called once after a run finishes, successfully
+// or not. e.Err holds the run error, if any. An error returned by the hook is
+// reported only if the run itself succeeded.
+func WithAfterRun(h Hook) Option {
+	return func(q *Queen) {
+		q.hooks.afterRun = append(q.hooks.afterRun, h)
+	}
+}
+
+// WithOnError registers a hook called when a migration fails, after its
+// transaction has been rolled back. Use it for alerting or audit events.
+func WithOnError(h Hook) Option {
+	return func(q *Queen) {
+		q.hooks.onError = append(q.hooks.onError, h)
+	}
+}
+
+// callHooks calls hooks in order and stops at the first error.
+func callHooks(ctx context.Context, name string, hooks []Hook, e HookEvent) error {
+	for _, h := range hooks {
+		if err := h(ctx, e); err != nil {
+			return fmt.Errorf("%s hook failed: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// withMigrationHooks runs fn between the BeforeMigration and AfterMigration hooks.
+func (q *Queen) withMigrationHooks(ctx context.Context, m *Migration, direction string, tx *sql.Tx, start time.Time, fn func() error) error {
+	event := HookEvent{Migration: m, Direction: direction, Tx: tx}
+	if err := callHooks(ctx, "before migration", q.hooks.beforeMigration, event); err != nil {
+		return err
+	}
+
+	if err := fn(); err != nil {
+		return err
+	}
+
+	event.Duration = time.Since(start)
+	return callHooks(ctx, "after migration", q.hooks.afterMigration, event)
+}
+
+// onMigrationError calls the OnError hooks for a failed migration.
+func (q *Queen) onMigrationError(ctx context.Context, m *Migration, direction string, start time.Time, err error) {
+	event := HookEvent{Migration: m, Direction: direction, Duration: time.Since(start), Err: err}
+	for _, h := range q.hooks.onError {
+		_ = h(ctx, event)
+	}
+}
+
+// withRunHooks runs fn between the BeforeRun and AfterRun hooks.
+func (q *Queen) withRunHooks(ctx context.Context, direction string, fn func() error) error {
+	start := time.Now()
+
+	if err := callHooks(ctx, "before run", q.hooks.beforeRun, HookEvent{Direction: direction}); err != nil {
+		return err
+	}
+
+	err := lockLostError(ctx, fn())
+
+	event := HookEvent{Direction: direction, Duration: time.Since(start), Err: err}
+	if hookErr := callHooks(ctx, "after run", q.hooks.afterRun, event); err == nil {
+		err = hookErr
+	}
+
+	return err
+}
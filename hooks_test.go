@@ -0,0 +1,121 @@
+package queen
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestHooks(t *testing.T) {
+	t.Run("called in order", func(t *testing.T) {
+		var calls []string
+		record := func(name string) Hook {
+			return func(ctx context.Context, e HookEvent) error {
+				call := name
+				if e.Migration != nil {
+					call += ":" + e.Migration.Version
+				}
+				calls = append(calls, call+":"+e.Direction)
+				return nil
+			}
+		}
+
+		q := New(newMemDriver(),
+			WithBeforeRun(record("before-run")),
+			WithAfterRun(record("after-run")),
+			WithBeforeMigration(record("before")),
+			WithAfterMigration(record("after")),
+		)
+		q.MustAdd(noopMigration("1", "first"))
+		q.MustAdd(noopMigration("2", "second"))
+
+		if err := q.Up(context.Background()); err != nil {
+			t.Fatalf("Up failed: %v", err)
+		}
+		if err := q.Down(context.Background(), 1); err != nil {
+			t.Fatalf("Down failed: %v", err)
+		}
+
+		want := []string{
+			"before-run:up",
+			"before:1:up", "after:1:up",
+			"before:2:up", "after:2:up",
+			"after-run:up",
+			"before-run:down",
+			"before:2:down", "after:2:down",
+			"after-run:down",
+		}
+		if !reflect.DeepEqual(calls, want) {
+			t.Errorf("calls = %v\nwant    %v", calls, want)
+		}
+	})
+
+	t.Run("before migration hook vetoes", func(t *testing.T) {
+		driver := newMemDriver()
+		veto := errors.New("not today")
+		var onError []HookEvent
+		var afterRun HookEvent
+
+		q := New(driver,
+			WithBeforeMigration(func(ctx context.Context, e HookEvent) error {
+				if e.Migration.Version == "2" {
+					return veto
+				}
+				return nil
+			}),
+			WithOnError(func(ctx context.Context, e HookEvent) error {
+				onError = append(onError, e)
+				return nil
+			}),
+			WithAfterRun(func(ctx context.Context, e HookEvent) error {
+				afterRun = e
+				return nil
+			}),
+		)
+		q.MustAdd(noopMigration("1", "first"))
+		q.MustAdd(noopMigration("2", "second"))
+
+		err := q.Up(context.Background())
+		if !errors.Is(err, veto) {
+			t.Fatalf("expected veto error, got %v", err)
+		}
+		if got := driver.appliedVersions(); !reflect.DeepEqual(got, []string{"1"}) {
+			t.Errorf("applied = %v, want [1]", got)
+		}
+		if len(onError) != 1 || onError[0].Migration.Version != "2" || !errors.Is(onError[0].Err, veto) {
+			t.Errorf("unexpected OnError events: %+v", onError)
+		}
+		if !errors.Is(afterRun.Err, veto) {
+			t.Errorf("AfterRun should receive run error, got %v", afterRun.Err)
+		}
+	})
+
+	t.Run("after run error is returned", func(t *testing.T) {
+		hookErr := errors.New("cache flush failed")
+		q := New(newMemDriver(), WithAfterRun(func(ctx context.Context, e HookEvent) error {
+			return hookErr
+		}))
+		q.MustAdd(noopMigration("1", "first"))
+
+		if err := q.Up(context.Background()); !errors.Is(err, hookErr) {
+			t.Errorf("expected after run error, got %v", err)
+		}
+	})
+
+	t.Run("run hooks skipped when nothing to do", func(t *testing.T) {
+		called := false
+		q := New(newMemDriver(), WithBeforeRun(func(ctx context.Context, e HookEvent) error {
+			called = true
+			return nil
+		}))
+		q.MustAdd(noopMigration("1", "first"))
+
+		if err := q.Down(context.Background(), 1); err != nil {
+			t.Fatalf("Down failed: %v", err)
+		}
+		if called {
+			t.Error("BeforeRun should not be called without migrations to run")
+		}
+	})
+}
@@ -25,6 +25,11 @@ func TestCompare(t *testing.T) {
 		{"001 < 010", "001", "010", -1},
 		{"010 < 100", "010", "100", -1},
 
+		// Timestamps (NamingPatternTimestamp)
+		{"timestamp order", "20261016153045", "20261016153046", -1},
+		{"timestamp across years", "20251231235959", "20260101000000", -1},
+		{"padded < timestamp", "999", "20261016153045", -1},
+
 		// Mixed alphanumeric
 		{"v1 < v2", "v1", "v2", -1},
 		{"v1 < v10", "v1", "v10", -1},
@@ -0,0 +1,158 @@
+package queen
+
+import (
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+
+	naturalsort "github.com/honeynil/queen/internal/sort"
+)
+
+const (
+	upSQLSuffix   = ".up.sql"
+	downSQLSuffix = ".down.sql"
+)
+
+// LoadFS registers SQL migrations stored as files in dir of fsys.
+//
+// Each migration consists of an up file and an optional down file:
+//
+//	migrations/001_create_users.up.sql
+//	migrations/001_create_users.down.sql
+//	migrations/002_add_email_index.up.sql
+//
+// The version and name are parsed from the file name ("001" and
+// "create_users" above) and validated the same way as migrations registered
+// with Add, including the configured NamingConfig. The version ends at the
+// first underscore, so versions that contain one (such as "users_001") can't
+// be loaded from files; use a dash or dot instead ("users-001_create.up.sql")
+// or register them with Add. Files that don't end in
+// .up.sql or .down.sql (such as seed.sql or schema.sql) and subdirectories
+// are ignored, so Go and other SQL files can live next to the migrations.
+//
+// All files are parsed and checked before any migration is registered. If
+// registration fails, the migrations added by this call are removed again,
+// so q is left as it was.
+//
+// SQL file migrations coexist with Go-function migrations in the same Queen
+// instance. Works with embed.FS and any other fs.FS implementation:
+//
+//	//go:embed migrations/*.sql
+//	var migrationsFS embed.FS
+//
+//	q := queen.New(driver)
+//	if err := queen.LoadFS(q, migrationsFS, "migrations"); err != nil {
+//	    log.Fatal(err)
+//	}
+//	q.MustAdd(queen.M{Version: "003", Name: "backfill", UpFunc: backfill})
+func LoadFS(q *Queen, fsys fs.FS, dir string) error {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return fmt.Errorf("failed to read migrations directory %s: %w", dir, err)
+	}
+
+	migrations := make(map[string]*Migration)
+
+	for _, entry := range entries {
+		if entry.IsDir() || !isSQLMigrationFile(entry.Name()) {
+			continue
+		}
+
+		filename := path.Join(dir, entry.Name())
+		version, name, up, err := parseSQLFileName(entry.Name())
+		if err != nil {
+			return fmt.Errorf("%w: %s: %v", ErrInvalidMigration, filename, err)
+		}
+
+		content, err := fs.ReadFile(fsys, filename)
+		if err != nil {
+			return fmt.Errorf("failed to read migration file %s: %w", filename, err)
+		}
+
+		m, ok := migrations[version]
+		if !ok {
+			m = &Migration{Version: version, Name: name}
+			migrations[version] = m
+		}
+		if m.Name != name {
+			return fmt.Errorf("%w: %s: name %q does not match %q for version %s",
+				ErrInvalidMigration, filename, name, m.Name, version)
+		}
+
+		if up {
+			if m.UpSQL != "" {
+				return fmt.Errorf("%w: %s: duplicate up file for version %s", ErrVersionConflict, filename, version)
+			}
+			m.UpSQL = string(content)
+		} else {
+			if m.DownSQL != "" {
+				return fmt.Errorf("%w: %s: duplicate down file for version %s", ErrVersionConflict, filename, version)
+			}
+			m.DownSQL = string(content)
+		}
+	}
+
+	versions := make([]string, 0, len(migrations))
+	for version := range migrations {
+		versions = append(versions, version)
+	}
+	sort.Slice(versions, func(i, j int) bool {
+		return naturalsort.Compare(versions[i], versions[j]) < 0
+	})
+
+	for _, version := range versions {
+		m := migrations[version]
+		if m.UpSQL == "" {
+			return fmt.Errorf("%w: migration %s (%s) has no %s file",
+				ErrInvalidMigration, version, m.Name, upSQLSuffix)
+		}
+	}
+
+	registered := len(q.migrations)
+	for _, version := range versions {
+		m := migrations[version]
+		if err := q.Add(*m); err != nil {
+			q.migrations = q.migrations[:registered]
+			return fmt.Errorf("failed to register migration %s (%s): %w", version, m.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// isSQLMigrationFile reports whether filename is an up or down SQL migration file.
+func isSQLMigrationFile(filename string) bool {
+	return strings.HasSuffix(filename, upSQLSuffix) || strings.HasSuffix(filename, downSQLSuffix)
+}
+
+// parseSQLFileName splits "001_create_users.up.sql" into version "001",
+// name "create_users" and direction (true for up, false for down).
+// The version ends at the first underscore; the name may contain more.
+func parseSQLFileName(filename string) (version, name string, up bool, err error) {
+	var base string
+	switch {
+	case strings.HasSuffix(filename, upSQLSuffix):
+		base = strings.TrimSuffix(filename, upSQLSuffix)
+		up = true
+	case strings.HasSuffix(filename, downSQLSuffix):
+		base = strings.TrimSuffix(filename, downSQLSuffix)
+	default:
+		return "", "", false, fmt.Errorf("file name must end with %s or %s", upSQLSuffix, downSQLSuffix)
+	}
+
+	version, name, ok := strings.Cut(base, "_")
+	if !ok || version == "" || name == "" {
+		return "", "", false, fmt.Errorf("file name must match <version>_<name>%s", upSQLSuffix)
+	}
+
+	if !IsValidMigrationVersion(version) {
+		return "", "", false, fmt.Errorf("invalid version %q", version)
+	}
+	if !IsValidMigrationName(name) {
+		return "", "", false, fmt.Errorf("invalid name %q: must contain only lowercase letters, numbers, and underscores", name)
+	}
+
+	return version, name, up, nil
+}
@@ -0,0 +1,175 @@
+package queen
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestLoadFS(t *testing.T) {
+	t.Run("loads up and down files", func(t *testing.T) {
+		fsys := fstest.MapFS{
+			"migrations/001_create_users.up.sql":   {Data: []byte("CREATE TABLE users (id INT)")},
+			"migrations/001_create_users.down.sql": {Data: []byte("DROP TABLE users")},
+			"migrations/010_add_index.up.sql":      {Data: []byte("CREATE INDEX idx ON users (id)")},
+			"migrations/002_add_email.up.sql":      {Data: []byte("ALTER TABLE users ADD email TEXT")},
+			"migrations/register.go":               {Data: []byte("package migrations")},
+			"migrations/README.md":                 {Data: []byte("docs")},
+			"migrations/seed.sql":                  {Data: []byte("INSERT INTO users VALUES (1)")},
+			"migrations/003_schema.sql":            {Data: []byte("CREATE TABLE users (id INT)")},
+		}
+
+		q := New(&testDriver{})
+		if err := LoadFS(q, fsys, "migrations"); err != nil {
+			t.Fatalf("LoadFS failed: %v", err)
+		}
+
+		if len(q.migrations) != 3 {
+			t.Fatalf("expected 3 migrations, got %d", len(q.migrations))
+		}
+
+		want := []string{"001", "002", "010"}
+		for i, m := range q.migrations {
+			if m.Version != want[i] {
+				t.Errorf("migration %d version = %q, want %q", i, m.Version, want[i])
+			}
+		}
+
+		first := q.migrations[0]
+		if first.Name != "create_users" {
+			t.Errorf("name = %q, want %q", first.Name, "create_users")
+		}
+		if first.UpSQL != "CREATE TABLE users (id INT)" || first.DownSQL != "DROP TABLE users" {
+			t.Errorf("unexpected SQL: up=%q down=%q", first.UpSQL, first.DownSQL)
+		}
+		if q.migrations[1].HasRollback() {
+			t.Error("migration without down file should have no rollback")
+		}
+	})
+
+	t.Run("coexists with Go function migrations", func(t *testing.T) {
+		fsys := fstest.MapFS{
+			"sql/001_create_users.up.sql": {Data: []byte("CREATE TABLE users (id INT)")},
+		}
+
+		q := New(&testDriver{})
+		q.MustAdd(M{
+			Version:        "002",
+			Name:           "seed_users",
+			ManualChecksum: "v1",
+			UpFunc:         func(ctx context.Context, tx *sql.Tx) error { return nil },
+		})
+		if err := LoadFS(q, fsys, "sql"); err != nil {
+			t.Fatalf("LoadFS failed: %v", err)
+		}
+		if len(q.migrations) != 2 {
+			t.Fatalf("expected 2 migrations, got %d", len(q.migrations))
+		}
+	})
+
+	t.Run("version ends at the first underscore", func(t *testing.T) {
+		fsys := fstest.MapFS{
+			"sql/users-001_create_users.up.sql": {Data: []byte("CREATE TABLE users (id INT)")},
+			"sql/002_add_user_email.up.sql":     {Data: []byte("ALTER TABLE users ADD email TEXT")},
+		}
+
+		q := New(&testDriver{})
+		if err := LoadFS(q, fsys, "sql"); err != nil {
+			t.Fatalf("LoadFS failed: %v", err)
+		}
+
+		got := map[string]string{}
+		for _, m := range q.migrations {
+			got[m.Version] = m.Name
+		}
+		want := map[string]string{"users-001": "create_users", "002": "add_user_email"}
+		if len(got) != len(want) || got["users-001"] != want["users-001"] || got["002"] != want["002"] {
+			t.Errorf("migrations = %v, want %v", got, want)
+		}
+	})
+
+	t.Run("registers nothing when a migration fails", func(t *testing.T) {
+		fsys := fstest.MapFS{
+			"sql/001_create_users.up.sql": {Data: []byte("CREATE TABLE users (id INT)")},
+			"sql/002_add_email.up.sql":    {Data: []byte("ALTER TABLE users ADD email TEXT")},
+			"sql/003_add_index.up.sql":    {Data: []byte("CREATE INDEX idx ON users (email)")},
+		}
+
+		q := New(&testDriver{})
+		q.MustAdd(M{Version: "002", Name: "seed_users", UpSQL: "SELECT 1"})
+
+		err := LoadFS(q, fsys, "sql")
+		if !errors.Is(err, ErrVersionConflict) {
+			t.Fatalf("error = %v, want %v", err, ErrVersionConflict)
+		}
+		if len(q.migrations) != 1 || q.migrations[0].Name != "seed_users" {
+			t.Errorf("migrations = %v, want only seed_users", q.migrations)
+		}
+	})
+
+	tests := []struct {
+		name        string
+		files       fstest.MapFS
+		naming      *NamingConfig
+		wantErr     error
+		errContains string
+	}{
+		{
+			name:        "down without up",
+			files:       fstest.MapFS{"m/001_create_users.down.sql": {Data: []byte("DROP TABLE users")}},
+			wantErr:     ErrInvalidMigration,
+			errContains: "has no .up.sql file",
+		},
+		{
+			name:        "missing name",
+			files:       fstest.MapFS{"m/001.up.sql": {Data: []byte("SELECT 1")}},
+			wantErr:     ErrInvalidMigration,
+			errContains: "<version>_<name>",
+		},
+		{
+			name:        "invalid name",
+			files:       fstest.MapFS{"m/001_Create-Users.up.sql": {Data: []byte("SELECT 1")}},
+			wantErr:     ErrInvalidMigration,
+			errContains: "invalid name",
+		},
+		{
+			name: "mismatched names",
+			files: fstest.MapFS{
+				"m/001_create_users.up.sql":  {Data: []byte("CREATE TABLE users (id INT)")},
+				"m/001_create_people.up.sql": {Data: []byte("CREATE TABLE people (id INT)")},
+			},
+			wantErr:     ErrInvalidMigration,
+			errContains: "does not match",
+		},
+		{
+			name:        "naming pattern enforced",
+			files:       fstest.MapFS{"m/1_create_users.up.sql": {Data: []byte("CREATE TABLE users (id INT)")}},
+			naming:      &NamingConfig{Pattern: NamingPatternSequentialPadded, Padding: 3, Enforce: true},
+			errContains: "naming pattern validation failed",
+		},
+		{
+			name:        "missing directory",
+			files:       fstest.MapFS{},
+			errContains: "failed to read migrations directory",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := NewWithConfig(&testDriver{}, &Config{Naming: tt.naming})
+			err := LoadFS(q, tt.files, "m")
+			if err == nil {
+				t.Fatal("expected error, got nil")
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("error = %v, want %v", err, tt.wantErr)
+			}
+			if !strings.Contains(err.Error(), tt.errContains) {
+				t.Errorf("error = %q, should contain %q", err, tt.errContains)
+			}
+		})
+	}
+}
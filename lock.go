@@ -0,0 +1,131 @@
+package queen
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// LockInfo describes the holder of the migration lock.
+type LockInfo struct {
+	// Key identifies the lock: lock key, lock name or advisory lock ID.
+	Key string `json:"key"`
+
+	// Owner identifies the holder: owner ID for table-based locks,
+	// backend PID or session ID for session locks.
+	Owner string `json:"owner"`
+
+	// Host is the host of the holder, if known.
+	Host string `json:"host,omitempty"`
+
+	// AcquiredAt is when the lock was acquired. Zero if unknown.
+	AcquiredAt time.Time `json:"acquired_at,omitzero"`
+
+	// ExpiresAt is when the lock expires. Zero for session locks,
+	// which are held until the holder's connection is closed.
+	ExpiresAt time.Time `json:"expires_at,omitzero"`
+}
+
+// Expired reports whether the lock has an expiration time in the past.
+func (l *LockInfo) Expired() bool {
+	return !l.ExpiresAt.IsZero() && l.ExpiresAt.Before(time.Now())
+}
+
+// LockInfo returns the current holder of the migration lock, or nil if the
+// lock is free. Returns ErrLockInfoUnsupported if the driver doesn't
+// implement LockInspector.
+func (q *Queen) LockInfo(ctx context.Context) (*LockInfo, error) {
+	inspector, err := q.lockInspector(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return inspector.LockInfo(ctx)
+}
+
+// ForceUnlock releases the migration lock held by any process, e.g. after a
+// deploy died while holding it. Make sure the holder is really gone: a
+// migration still running would lose its lock.
+//
+// Returns ErrLockInfoUnsupported if the driver doesn't implement LockInspector.
+func (q *Queen) ForceUnlock(ctx context.Context) error {
+	inspector, err := q.lockInspector(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := inspector.ForceUnlock(ctx); err != nil {
+		return fmt.Errorf("failed to release lock: %w", err)
+	}
+
+	q.logger.WarnContext(ctx, "lock released by force", "table", q.config.TableName)
+	return nil
+}
+
+func (q *Queen) lockInspector(ctx context.Context) (LockInspector, error) {
+	if q.driver == nil {
+		return nil, ErrNoDriver
+	}
+
+	inspector, ok := q.driver.(LockInspector)
+	if !ok {
+		return nil, ErrLockInfoUnsupported
+	}
+
+	if err := q.driver.Init(ctx); err != nil {
+		return nil, err
+	}
+
+	return inspector, nil
+}
+
+// watchLock returns a context that is cancelled when the driver loses its
+// lock lease, and a function that stops watching. It must be called after
+// Lock succeeds; stop must be called before Unlock.
+func (q *Queen) watchLock(ctx context.Context) (context.Context, func()) {
+	leaser, ok := q.driver.(LeaseLocker)
+	if !ok {
+		return ctx, func() {}
+	}
+
+	lost := leaser.LockLost()
+	if lost == nil {
+		return ctx, func() {}
+	}
+
+	ctx, cancel := context.WithCancelCause(ctx)
+	done := make(chan struct{})
+
+	go func() {
+		select {
+		case <-lost:
+			err := fmt.Errorf("%w: %v", ErrLockLost, leaser.LockErr())
+			q.logger.ErrorContext(ctx, "lock lease lost, cancelling migrations",
+				"table", q.config.TableName,
+				"error", err)
+			cancel(err)
+		case <-done:
+		}
+	}()
+
+	return ctx, func() {
+		close(done)
+		cancel(nil)
+	}
+}
+
+// lockLostError reports ErrLockLost instead of a bare context.Canceled
+// when err was caused by losing the lock lease.
+func lockLostError(ctx context.Context, err error) error {
+	if err == nil || ctx.Err() == nil {
+		return err
+	}
+
+	cause := context.Cause(ctx)
+	if !errors.Is(cause, ErrLockLost) || errors.Is(err, ErrLockLost) {
+		return err
+	}
+
+	return fmt.Errorf("%w (%v)", cause, err)
+}
@@ -0,0 +1,59 @@
+package queen
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+)
+
+// leaseDriver is a memDriver whose lock lease can be lost on demand.
+type leaseDriver struct {
+	*memDriver
+	lost chan struct{}
+}
+
+func (d *leaseDriver) LockLost() <-chan struct{} { return d.lost }
+func (d *leaseDriver) LockErr() error            { return errors.New("renewal failed") }
+
+func TestLockLost(t *testing.T) {
+	t.Parallel()
+
+	driver := &leaseDriver{memDriver: newMemDriver(), lost: make(chan struct{})}
+	q := New(driver)
+	q.MustAdd(M{
+		Version:        "001",
+		Name:           "backfill",
+		ManualChecksum: "v1",
+		UpFunc: func(ctx context.Context, tx *sql.Tx) error {
+			close(driver.lost)
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(time.Second):
+				return errors.New("context was not cancelled")
+			}
+		},
+	})
+
+	err := q.Up(context.Background())
+	if !errors.Is(err, ErrLockLost) {
+		t.Fatalf("Up() error = %v, want ErrLockLost", err)
+	}
+	if len(driver.applied) != 0 {
+		t.Errorf("applied = %v, want none", driver.appliedVersions())
+	}
+}
+
+func TestLockInfoUnsupported(t *testing.T) {
+	t.Parallel()
+
+	q := New(newMemDriver())
+	if _, err := q.LockInfo(context.Background()); !errors.Is(err, ErrLockInfoUnsupported) {
+		t.Errorf("LockInfo() error = %v, want ErrLockInfoUnsupported", err)
+	}
+	if err := q.ForceUnlock(context.Background()); !errors.Is(err, ErrLockInfoUnsupported) {
+		t.Errorf("ForceUnlock() error = %v, want ErrLockInfoUnsupported", err)
+	}
+}
@@ -3,6 +3,7 @@ package queen
 import (
 	"context"
 	"database/sql"
+	"fmt"
 	"strings"
 	"sync"
 
@@ -13,6 +14,11 @@ import (
 // It receives a context and a transaction, and should return an error if the migration fails.
 type MigrationFunc func(ctx context.Context, tx *sql.Tx) error
 
+// MigrationConnFunc is a function that executes a migration outside of a transaction.
+// It receives a dedicated connection from the pool and is used together with
+// Migration.NoTransaction for statements that cannot run inside a transaction.
+type MigrationConnFunc func(ctx context.Context, conn *sql.Conn) error
+
 // Migration represents a single database migration.
 //
 // A migration can be defined using SQL strings (UpSQL/DownSQL) or
@@ -28,6 +34,18 @@ type MigrationFunc func(ctx context.Context, tx *sql.Tx) error
 // Name should be a human-readable description like "create_users" or
 // "add_email_index".
 //
+// # Groups
+//
+// Modules that own different tables can keep independent version sequences
+// in one database by setting Group:
+//
+//	queen.M{Group: "billing", Version: "001", Name: "create_invoices", ...}
+//	queen.M{Group: "auth", Version: "001", Name: "create_sessions", ...}
+//
+// Migrations are ordered within their group, and out-of-order detection
+// compares only versions of the same group. Config.Group restricts Up, Down
+// and Status to one group.
+//
 // # SQL Migrations
 //
 // For simple schema changes, use UpSQL and DownSQL:
@@ -56,6 +74,24 @@ type MigrationFunc func(ctx context.Context, tx *sql.Tx) error
 // IMPORTANT: When using UpFunc/DownFunc, always set ManualChecksum to track
 // changes. Update it whenever you modify the function (e.g., "v1" -> "v2").
 //
+// # Non-Transactional Migrations
+//
+// Some statements cannot run inside a transaction, e.g. PostgreSQL
+// CREATE INDEX CONCURRENTLY, ALTER TYPE ... ADD VALUE or SQLite VACUUM.
+// Set NoTransaction to execute UpSQL/DownSQL directly on a connection,
+// or use UpConnFunc/DownConnFunc for Go code:
+//
+//	queen.M{
+//	    Version:       "003",
+//	    Name:          "add_email_index",
+//	    NoTransaction: true,
+//	    UpSQL:         "CREATE INDEX CONCURRENTLY idx_users_email ON users (email)",
+//	    DownSQL:       "DROP INDEX CONCURRENTLY idx_users_email",
+//	}
+//
+// A failed non-transactional migration is not rolled back and may leave
+// partial changes behind.
+//
 // # Checksums
 //
 // Queen automatically calculates checksums for SQL migrations. For Go function
@@ -70,6 +106,15 @@ type Migration struct {
 	// Examples: "create_users", "add_email_index"
 	Name string
 
+	// Group puts the migration on an independent track with its own version
+	// sequence, e.g. one group per module of a monolith. Default: "" (ungrouped)
+	//
+	// Versions only need to be unique within a group. Add records the migration
+	// as "<group>/<version>" (e.g. "billing/001"), which is also the version
+	// used by Status, MigrateTo and the repair commands. Setting Version to
+	// "billing/001" directly is equivalent.
+	Group string
+
 	// UpSQL applies the migration using SQL.
 	// Leave empty when using UpFunc.
 	UpSQL string
@@ -86,6 +131,20 @@ type Migration struct {
 	// Optional but recommended for safe rollbacks.
 	DownFunc MigrationFunc
 
+	// NoTransaction executes the migration outside of a transaction.
+	// Required for statements like CREATE INDEX CONCURRENTLY.
+	// Cannot be combined with UpFunc/DownFunc; use UpConnFunc/DownConnFunc instead.
+	// Default: false
+	NoTransaction bool
+
+	// UpConnFunc applies the migration using Go code on a dedicated connection.
+	// Only valid when NoTransaction is true.
+	UpConnFunc MigrationConnFunc
+
+	// DownConnFunc rolls back the migration using Go code on a dedicated connection.
+	// Only valid when NoTransaction is true.
+	DownConnFunc MigrationConnFunc
+
 	// ManualChecksum tracks changes to function migrations.
 	// Required when using UpFunc/DownFunc for validation.
 	// Examples: "v1", "v2", "normalize-emails-v1"
@@ -129,7 +188,11 @@ type M = Migration
 
 // Validate ensures Version, Name, and at least one Up method are defined.
 func (m *Migration) Validate() error {
-	if m.Version == "" || strings.Contains(m.Version, " ") || !IsValidMigrationName(m.Version){
+	group, version := splitGroup(m.Version)
+	if version == "" || strings.Contains(version, " ") || !IsValidMigrationVersion(version) {
+		return ErrInvalidMigration
+	}
+	if strings.Contains(m.Version, groupSeparator) && !IsValidMigrationName(group) {
 		return ErrInvalidMigration
 	}
 
@@ -142,7 +205,15 @@ func (m *Migration) Validate() error {
 	}
 
 	// Must have at least one Up method
-	if m.UpSQL == "" && m.UpFunc == nil {
+	if m.UpSQL == "" && m.UpFunc == nil && m.UpConnFunc == nil {
+		return ErrInvalidMigration
+	}
+
+	// Transaction functions need a transaction, connection functions must not have one
+	if m.NoTransaction && (m.UpFunc != nil || m.DownFunc != nil) {
+		return ErrInvalidMigration
+	}
+	if !m.NoTransaction && (m.UpConnFunc != nil || m.DownConnFunc != nil) {
 		return ErrInvalidMigration
 	}
 
@@ -212,9 +283,9 @@ func (m *Migration) Checksum() string {
 	return m.checksum
 }
 
-// HasRollback checks if DownSQL or DownFunc is defined.
+// HasRollback checks if DownSQL, DownFunc or DownConnFunc is defined.
 func (m *Migration) HasRollback() bool {
-	return m.DownSQL != "" || m.DownFunc != nil
+	return m.DownSQL != "" || m.DownFunc != nil || m.DownConnFunc != nil
 }
 
 // IsDestructive checks DownSQL for destructive keywords: DROP TABLE, DROP DATABASE, TRUNCATE, etc.
@@ -243,29 +314,87 @@ func (m *Migration) IsDestructive() bool {
 }
 
 // executeUp runs UpFunc or UpSQL within the transaction.
-func (m *Migration) executeUp(ctx context.Context, tx *sql.Tx) error {
+// UpSQL is executed statement by statement when splitter is not nil.
+func (m *Migration) executeUp(ctx context.Context, tx *sql.Tx, splitter StatementSplitter) error {
 	if m.UpFunc != nil {
 		return m.UpFunc(ctx, tx)
 	}
 
 	if m.UpSQL != "" {
-		_, err := tx.ExecContext(ctx, m.UpSQL)
-		return err
+		return execSQL(ctx, tx, m.UpSQL, splitter)
 	}
 
 	return ErrInvalidMigration
 }
 
 // executeDown runs DownFunc or DownSQL within the transaction.
-func (m *Migration) executeDown(ctx context.Context, tx *sql.Tx) error {
+// DownSQL is executed statement by statement when splitter is not nil.
+func (m *Migration) executeDown(ctx context.Context, tx *sql.Tx, splitter StatementSplitter) error {
 	if m.DownFunc != nil {
 		return m.DownFunc(ctx, tx)
 	}
 
 	if m.DownSQL != "" {
-		_, err := tx.ExecContext(ctx, m.DownSQL)
-		return err
+		return execSQL(ctx, tx, m.DownSQL, splitter)
+	}
+
+	return ErrInvalidMigration
+}
+
+// executeUpConn runs UpConnFunc or UpSQL on a connection without a transaction.
+func (m *Migration) executeUpConn(ctx context.Context, conn *sql.Conn, splitter StatementSplitter) error {
+	if m.UpConnFunc != nil {
+		return m.UpConnFunc(ctx, conn)
+	}
+
+	if m.UpSQL != "" {
+		return execSQL(ctx, conn, m.UpSQL, splitter)
+	}
+
+	return ErrInvalidMigration
+}
+
+// executeDownConn runs DownConnFunc or DownSQL on a connection without a transaction.
+func (m *Migration) executeDownConn(ctx context.Context, conn *sql.Conn, splitter StatementSplitter) error {
+	if m.DownConnFunc != nil {
+		return m.DownConnFunc(ctx, conn)
+	}
+
+	if m.DownSQL != "" {
+		return execSQL(ctx, conn, m.DownSQL, splitter)
 	}
 
 	return ErrInvalidMigration
 }
+
+// execer is implemented by *sql.Tx and *sql.Conn.
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+// execSQL executes query as a whole, or statement by statement when splitter is not nil.
+// A failing statement is reported as a *StatementError.
+func execSQL(ctx context.Context, db execer, query string, splitter StatementSplitter) error {
+	if splitter == nil {
+		_, err := db.ExecContext(ctx, query)
+		return err
+	}
+
+	statements, err := splitter.SplitStatements(query)
+	if err != nil {
+		return fmt.Errorf("%w: failed to split SQL: %v", ErrInvalidMigration, err)
+	}
+
+	for i, stmt := range statements {
+		if _, err := db.ExecContext(ctx, stmt); err != nil {
+			return &StatementError{
+				Index:     i + 1,
+				Total:     len(statements),
+				Statement: stmt,
+				Cause:     err,
+			}
+		}
+	}
+
+	return nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"strings"
 	"testing"
 )
 
@@ -67,6 +68,51 @@ func TestMigrationValidate(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "valid non-transactional SQL migration",
+			m: Migration{
+				Version:       "001",
+				Name:          "add_index",
+				NoTransaction: true,
+				UpSQL:         "CREATE INDEX CONCURRENTLY idx ON users (email)",
+			},
+			wantErr: false,
+		},
+		{
+			name: "valid non-transactional Go migration",
+			m: Migration{
+				Version:       "001",
+				Name:          "vacuum",
+				NoTransaction: true,
+				UpConnFunc: func(ctx context.Context, conn *sql.Conn) error {
+					return nil
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "NoTransaction with transaction function",
+			m: Migration{
+				Version:       "001",
+				Name:          "seed_data",
+				NoTransaction: true,
+				UpFunc: func(ctx context.Context, tx *sql.Tx) error {
+					return nil
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "connection function without NoTransaction",
+			m: Migration{
+				Version: "001",
+				Name:    "vacuum",
+				UpConnFunc: func(ctx context.Context, conn *sql.Conn) error {
+					return nil
+				},
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -253,7 +299,7 @@ func TestMigrationExecuteUp(t *testing.T) {
 			// No Up method
 		}
 
-		err := m.executeUp(context.Background(), nil)
+		err := m.executeUp(context.Background(), nil, nil)
 		if !errors.Is(err, ErrInvalidMigration) {
 			t.Errorf("Expected ErrInvalidMigration, got %v", err)
 		}
@@ -271,10 +317,88 @@ func TestMigrationExecuteUp(t *testing.T) {
 			},
 		}
 
-		m.executeUp(context.Background(), nil)
+		m.executeUp(context.Background(), nil, nil)
 
 		if !called {
 			t.Error("UpFunc was not called")
 		}
 	})
 }
+
+// fakeExecer records executed statements and fails on failOn.
+type fakeExecer struct {
+	executed []string
+	failOn   string
+}
+
+func (e *fakeExecer) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	if query == e.failOn {
+		return nil, errors.New("syntax error")
+	}
+	e.executed = append(e.executed, query)
+	return nil, nil
+}
+
+// semicolonSplitter splits on every semicolon.
+type semicolonSplitter struct{}
+
+func (semicolonSplitter) SplitStatements(query string) ([]string, error) {
+	var statements []string
+	for _, stmt := range strings.Split(query, ";") {
+		if stmt = strings.TrimSpace(stmt); stmt != "" {
+			statements = append(statements, stmt)
+		}
+	}
+	return statements, nil
+}
+
+func TestExecSQL(t *testing.T) {
+	const script = "CREATE TABLE a (id INT); CREATE TABLE b (id INT); CREATE TABLE c (id INT)"
+
+	t.Run("without splitter executes script as a whole", func(t *testing.T) {
+		db := &fakeExecer{}
+		if err := execSQL(context.Background(), db, script, nil); err != nil {
+			t.Fatalf("execSQL failed: %v", err)
+		}
+		if len(db.executed) != 1 {
+			t.Errorf("executed %d queries, want 1", len(db.executed))
+		}
+	})
+
+	t.Run("with splitter executes each statement", func(t *testing.T) {
+		db := &fakeExecer{}
+		if err := execSQL(context.Background(), db, script, semicolonSplitter{}); err != nil {
+			t.Fatalf("execSQL failed: %v", err)
+		}
+		if len(db.executed) != 3 {
+			t.Errorf("executed %d statements, want 3", len(db.executed))
+		}
+	})
+
+	t.Run("failing statement is reported", func(t *testing.T) {
+		db := &fakeExecer{failOn: "CREATE TABLE b (id INT)"}
+		err := execSQL(context.Background(), db, script, semicolonSplitter{})
+
+		var stmtErr *StatementError
+		if !errors.As(err, &stmtErr) {
+			t.Fatalf("expected StatementError, got %v", err)
+		}
+		if stmtErr.Index != 2 || stmtErr.Total != 3 {
+			t.Errorf("statement %d of %d, want 2 of 3", stmtErr.Index, stmtErr.Total)
+		}
+		if len(db.executed) != 1 {
+			t.Errorf("executed %d statements before failure, want 1", len(db.executed))
+		}
+
+		var migErr *MigrationError
+		if !errors.As(newMigrationError("001", "create_tables", "up", "mysql", err), &migErr) {
+			t.Fatal("expected MigrationError")
+		}
+		if migErr.StatementIndex != 2 || migErr.Statement != "CREATE TABLE b (id INT)" {
+			t.Errorf("MigrationError statement = %d %q", migErr.StatementIndex, migErr.Statement)
+		}
+		if !strings.Contains(migErr.Error(), "statement 2 of 3") {
+			t.Errorf("error message should contain statement position: %s", migErr.Error())
+		}
+	})
+}
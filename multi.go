@@ -0,0 +1,282 @@
+package queen
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Target is one database or schema migrated by a MultiRunner,
+// e.g. a tenant in a schema-per-tenant deployment.
+type Target struct {
+	// Name identifies the target in results (tenant, schema or database name).
+	Name string
+
+	// Open creates the driver of the target. It is called when the target's
+	// turn comes, so at most MultiRunner.Concurrency connections are open at
+	// once. The runner closes the driver when the target is done.
+	Open func(ctx context.Context) (Driver, error)
+}
+
+// TargetResult is the outcome of a MultiRunner operation on one target.
+type TargetResult struct {
+	// Target is the target name.
+	Target string
+
+	// Applied is the number of migrations applied by Up.
+	Applied int
+
+	// Pending is the number of migrations still pending afterwards.
+	Pending int
+
+	// Current is the newest applied version, or empty if none.
+	Current string
+
+	// Statuses is the full migration status. Only set by Status.
+	Statuses []MigrationStatus
+
+	// Duration is how long the operation took on this target.
+	Duration time.Duration
+
+	// Err is the failure, or nil if the operation succeeded.
+	Err error
+}
+
+// MultiResult holds per-target results in the order of the targets.
+type MultiResult []TargetResult
+
+// Failed returns the results of targets whose operation failed.
+func (r MultiResult) Failed() MultiResult {
+	var failed MultiResult
+	for _, t := range r {
+		if t.Err != nil {
+			failed = append(failed, t)
+		}
+	}
+	return failed
+}
+
+// Err returns an ErrTargetsFailed error naming the failed targets,
+// or nil if all targets succeeded.
+func (r MultiResult) Err() error {
+	failed := r.Failed()
+	if len(failed) == 0 {
+		return nil
+	}
+
+	names := make([]string, len(failed))
+	for i, t := range failed {
+		names[i] = t.Target
+	}
+	return fmt.Errorf("%w: %d of %d (%s)", ErrTargetsFailed, len(failed), len(r), strings.Join(names, ", "))
+}
+
+// MultiRunner applies the same set of migrations to many targets,
+// such as hundreds of tenant schemas or databases.
+//
+// Each target gets its own Queen instance, driver and lock, so a failing
+// or locked tenant doesn't block the others. Operations run on up to
+// Concurrency targets at once and never stop early: every target is
+// attempted and reported in the returned MultiResult.
+//
+// Example:
+//
+//	targets := make([]queen.Target, 0, len(tenants))
+//	for _, tenant := range tenants {
+//	    targets = append(targets, queen.Target{
+//	        Name: tenant,
+//	        Open: func(ctx context.Context) (queen.Driver, error) {
+//	            db, err := sql.Open("pgx", dsn)
+//	            if err != nil {
+//	                return nil, err
+//	            }
+//	            return postgres.New(db, postgres.WithSchema(tenant)), nil
+//	        },
+//	    })
+//	}
+//
+//	runner := queen.NewMultiRunner(targets, migrations.Register)
+//	runner.Concurrency = 8
+//	results, err := runner.Up(ctx)
+//	for _, r := range results.Failed() {
+//	    log.Printf("tenant %s: %v", r.Target, r.Err)
+//	}
+type MultiRunner struct {
+	// Concurrency limits how many targets are processed at once. Default: 4
+	Concurrency int
+
+	// Config is copied for each target's Queen instance. Default: DefaultConfig()
+	Config *Config
+
+	// Options are applied to each target's Queen instance (e.g. WithLogger).
+	Options []Option
+
+	targets  []Target
+	register func(*Queen)
+}
+
+// defaultConcurrency is the number of targets processed at once when
+// MultiRunner.Concurrency is not set.
+const defaultConcurrency = 4
+
+// NewMultiRunner creates a runner for targets. register adds the migrations
+// to each target's Queen instance, like the function passed to cli.Run.
+func NewMultiRunner(targets []Target, register func(*Queen)) *MultiRunner {
+	return &MultiRunner{
+		targets:  targets,
+		register: register,
+	}
+}
+
+// Up applies all pending migrations on every target.
+//
+// The returned error is MultiResult.Err: nil if all targets succeeded.
+func (m *MultiRunner) Up(ctx context.Context) (MultiResult, error) {
+	return m.run(ctx, func(ctx context.Context, q *Queen, r *TargetResult) error {
+		before, err := q.Status(ctx)
+		if err != nil {
+			return err
+		}
+
+		upErr := q.Up(ctx)
+
+		after, err := q.Status(ctx)
+		if err != nil {
+			if upErr != nil {
+				return upErr
+			}
+			return err
+		}
+
+		summarize(r, after)
+		r.Applied = countPending(before) - r.Pending
+		return upErr
+	})
+}
+
+// Status reads the migration status of every target.
+//
+// The returned error is MultiResult.Err: nil if all targets succeeded.
+func (m *MultiRunner) Status(ctx context.Context) (MultiResult, error) {
+	return m.run(ctx, func(ctx context.Context, q *Queen, r *TargetResult) error {
+		statuses, err := q.Status(ctx)
+		if err != nil {
+			return err
+		}
+
+		summarize(r, statuses)
+		r.Statuses = statuses
+		return nil
+	})
+}
+
+// Validate validates migrations against every target.
+//
+// The returned error is MultiResult.Err: nil if all targets succeeded.
+func (m *MultiRunner) Validate(ctx context.Context) (MultiResult, error) {
+	return m.run(ctx, func(ctx context.Context, q *Queen, r *TargetResult) error {
+		if err := q.Validate(ctx); err != nil {
+			return err
+		}
+
+		statuses, err := q.Status(ctx)
+		if err != nil {
+			return err
+		}
+
+		summarize(r, statuses)
+		return nil
+	})
+}
+
+// run calls op on every target with bounded concurrency.
+func (m *MultiRunner) run(ctx context.Context, op func(context.Context, *Queen, *TargetResult) error) (MultiResult, error) {
+	concurrency := m.Concurrency
+	if concurrency <= 0 {
+		concurrency = defaultConcurrency
+	}
+
+	results := make(MultiResult, len(m.targets))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+
+	for i, target := range m.targets {
+		results[i].Target = target.Name
+
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			results[i].Err = ctx.Err()
+			continue
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			start := time.Now()
+			results[i].Err = m.runTarget(ctx, target, &results[i], op)
+			results[i].Duration = time.Since(start)
+		}()
+	}
+
+	wg.Wait()
+	return results, results.Err()
+}
+
+// runTarget opens the target, runs op on a fresh Queen instance and closes it.
+func (m *MultiRunner) runTarget(ctx context.Context, target Target, r *TargetResult, op func(context.Context, *Queen, *TargetResult) error) error {
+	if target.Open == nil {
+		return ErrNoDriver
+	}
+
+	driver, err := target.Open(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to open target: %w", err)
+	}
+
+	config := DefaultConfig()
+	if m.Config != nil {
+		c := *m.Config
+		config = &c
+	}
+
+	q := NewWithConfig(driver, config)
+	for _, opt := range m.Options {
+		opt(q)
+	}
+	if m.register != nil {
+		m.register(q)
+	}
+	defer func() { _ = q.Close() }()
+
+	return op(ctx, q, r)
+}
+
+// summarize fills the pending count and current version of r from statuses.
+func summarize(r *TargetResult, statuses []MigrationStatus) {
+	r.Pending = countPending(statuses)
+	r.Current = ""
+	for _, s := range statuses {
+		if s.AppliedAt == nil || s.Status == StatusOrphaned {
+			continue
+		}
+		if r.Current == "" || compareVersions(s.Version, r.Current) > 0 {
+			r.Current = s.Version
+		}
+	}
+}
+
+// countPending counts migrations that Up would apply, including out-of-order ones.
+func countPending(statuses []MigrationStatus) int {
+	n := 0
+	for _, s := range statuses {
+		if s.Status == StatusPending || s.Status == StatusOutOfOrder {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,166 @@
+package queen
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// lockedDriver is a memDriver whose lock is held by someone else.
+type lockedDriver struct {
+	*memDriver
+}
+
+func (d *lockedDriver) Lock(ctx context.Context, timeout time.Duration) error {
+	return ErrLockTimeout
+}
+
+func registerTwo(q *Queen) {
+	q.MustAdd(noopMigration("001", "first"))
+	q.MustAdd(noopMigration("002", "second"))
+}
+
+func TestMultiRunner(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Up reports per-target results", func(t *testing.T) {
+		t.Parallel()
+
+		fresh := newMemDriver()
+		partial := newMemDriver()
+		partial.applied["001"] = Applied{Version: "001", Name: "first", AppliedAt: time.Now(), Checksum: "v1"}
+
+		targets := []Target{
+			{Name: "fresh", Open: func(context.Context) (Driver, error) { return fresh, nil }},
+			{Name: "partial", Open: func(context.Context) (Driver, error) { return partial, nil }},
+			{Name: "locked", Open: func(context.Context) (Driver, error) { return &lockedDriver{newMemDriver()}, nil }},
+			{Name: "unreachable", Open: func(context.Context) (Driver, error) { return nil, errors.New("connection refused") }},
+		}
+
+		results, err := NewMultiRunner(targets, registerTwo).Up(context.Background())
+		if !errors.Is(err, ErrTargetsFailed) {
+			t.Fatalf("Up() error = %v, want ErrTargetsFailed", err)
+		}
+		if !strings.Contains(err.Error(), "locked, unreachable") {
+			t.Errorf("Up() error = %q, want failed target names", err)
+		}
+
+		if len(results) != 4 {
+			t.Fatalf("len(results) = %d, want 4", len(results))
+		}
+		for i, want := range []struct {
+			target           string
+			applied, pending int
+			current          string
+			failed           bool
+		}{
+			{"fresh", 2, 0, "002", false},
+			{"partial", 1, 0, "002", false},
+			{"locked", 0, 2, "", true},
+			{"unreachable", 0, 0, "", true},
+		} {
+			r := results[i]
+			if r.Target != want.target || r.Applied != want.applied || r.Pending != want.pending ||
+				r.Current != want.current || (r.Err != nil) != want.failed {
+				t.Errorf("results[%d] = {%s applied=%d pending=%d current=%q err=%v}, want %+v",
+					i, r.Target, r.Applied, r.Pending, r.Current, r.Err, want)
+			}
+		}
+
+		if !errors.Is(results[2].Err, ErrLockTimeout) {
+			t.Errorf("locked target error = %v, want ErrLockTimeout", results[2].Err)
+		}
+		if failed := results.Failed(); len(failed) != 2 {
+			t.Errorf("Failed() = %d results, want 2", len(failed))
+		}
+	})
+
+	t.Run("Current orders grouped versions by group", func(t *testing.T) {
+		t.Parallel()
+
+		registerGroups := func(q *Queen) {
+			q.MustAdd(groupMigration("billing", "2", "payments"))
+			q.MustAdd(groupMigration("billing", "10", "invoices"))
+			q.MustAdd(groupMigration("billing2", "1", "refunds"))
+		}
+		driver := newMemDriver()
+		targets := []Target{{Name: "tenant", Open: func(context.Context) (Driver, error) { return driver, nil }}}
+
+		// Natural sort would pick billing/10, but billing2 sorts after billing
+		results, err := NewMultiRunner(targets, registerGroups).Up(context.Background())
+		if err != nil {
+			t.Fatalf("Up() error = %v", err)
+		}
+		if got := results[0].Current; got != "billing2/1" {
+			t.Errorf("Current = %q, want billing2/1", got)
+		}
+
+		runner := NewMultiRunner(targets, registerGroups)
+		runner.Config = &Config{Group: "billing"}
+		results, err = runner.Status(context.Background())
+		if err != nil {
+			t.Fatalf("Status() error = %v", err)
+		}
+		if got := results[0].Current; got != "billing/10" {
+			t.Errorf("Current with Config.Group = %q, want billing/10", got)
+		}
+	})
+
+	t.Run("Status succeeds on all targets", func(t *testing.T) {
+		t.Parallel()
+
+		targets := []Target{
+			{Name: "a", Open: func(context.Context) (Driver, error) { return newMemDriver(), nil }},
+			{Name: "b", Open: func(context.Context) (Driver, error) { return newMemDriver(), nil }},
+		}
+
+		results, err := NewMultiRunner(targets, registerTwo).Status(context.Background())
+		if err != nil {
+			t.Fatalf("Status() error = %v", err)
+		}
+		for _, r := range results {
+			if len(r.Statuses) != 2 || r.Pending != 2 {
+				t.Errorf("%s: statuses = %d, pending = %d, want 2 and 2", r.Target, len(r.Statuses), r.Pending)
+			}
+		}
+	})
+
+	t.Run("concurrency is bounded", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			mu            sync.Mutex
+			running, peak int
+		)
+		open := func(context.Context) (Driver, error) {
+			mu.Lock()
+			running++
+			peak = max(peak, running)
+			mu.Unlock()
+
+			time.Sleep(5 * time.Millisecond)
+
+			mu.Lock()
+			running--
+			mu.Unlock()
+			return newMemDriver(), nil
+		}
+
+		targets := make([]Target, 10)
+		for i := range targets {
+			targets[i] = Target{Name: string(rune('a' + i)), Open: open}
+		}
+
+		runner := NewMultiRunner(targets, registerTwo)
+		runner.Concurrency = 3
+		if _, err := runner.Up(context.Background()); err != nil {
+			t.Fatalf("Up() error = %v", err)
+		}
+		if peak > 3 {
+			t.Errorf("peak concurrency = %d, want <= 3", peak)
+		}
+	})
+}
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"regexp"
 	"strconv"
+	"strings"
+	"time"
 )
 
 // NamingPattern defines the migration version naming convention.
@@ -22,8 +24,40 @@ const (
 
 	// NamingPatternSemver enforces semantic versioning: 1.0.0, 1.1.0, 2.0.0, ...
 	NamingPatternSemver NamingPattern = "semver"
+
+	// NamingPatternTimestamp enforces UTC timestamps: 20261016153045, ...
+	// Versions created on parallel branches rarely collide, and they sort
+	// chronologically with natural sort.
+	NamingPatternTimestamp NamingPattern = "timestamp"
 )
 
+// TimestampLayout is the time layout of NamingPatternTimestamp versions.
+const TimestampLayout = "20060102150405"
+
+// VersionBump selects the part of a semantic version incremented by NextSemver.
+type VersionBump string
+
+const (
+	// BumpMajor increments the major version and resets minor and patch: 1.4.2 -> 2.0.0
+	BumpMajor VersionBump = "major"
+
+	// BumpMinor increments the minor version and resets patch: 1.4.2 -> 1.5.0
+	BumpMinor VersionBump = "minor"
+
+	// BumpPatch increments the patch version: 1.4.2 -> 1.4.3
+	BumpPatch VersionBump = "patch"
+)
+
+// ParseVersionBump converts a string ("major", "minor", "patch") to a VersionBump.
+func ParseVersionBump(s string) (VersionBump, error) {
+	switch b := VersionBump(strings.ToLower(s)); b {
+	case BumpMajor, BumpMinor, BumpPatch:
+		return b, nil
+	default:
+		return "", fmt.Errorf("invalid version bump %q (must be %s, %s or %s)", s, BumpMajor, BumpMinor, BumpPatch)
+	}
+}
+
 // NamingConfig configures migration version naming validation.
 type NamingConfig struct {
 	// Pattern specifies the naming pattern to enforce.
@@ -63,6 +97,8 @@ func (nc *NamingConfig) Validate(version string) error {
 		return validateSequentialPadded(version, nc.Padding)
 	case NamingPatternSemver:
 		return validateSemver(version)
+	case NamingPatternTimestamp:
+		return validateTimestamp(version)
 	default:
 		return fmt.Errorf("unknown naming pattern: %s", nc.Pattern)
 	}
@@ -109,8 +145,21 @@ func validateSemver(version string) error {
 	return nil
 }
 
+// validateTimestamp checks if version is a valid UTC timestamp (20261016153045).
+func validateTimestamp(version string) error {
+	if _, err := time.Parse(TimestampLayout, version); err != nil || len(version) != len(TimestampLayout) {
+		return fmt.Errorf("version must be a timestamp in YYYYMMDDHHMMSS format (e.g., 20261016153045): got %q", version)
+	}
+
+	return nil
+}
+
 // FindNextVersion finds the next version based on the pattern and existing versions.
 // This is primarily used by CLI tools for auto-generating version numbers.
+//
+// For NamingPatternSemver it bumps the patch version (see NextSemver), and for
+// NamingPatternTimestamp it returns the current UTC time, or one second after
+// the newest existing version if that is not in the past.
 func (nc *NamingConfig) FindNextVersion(existingVersions []string) (string, error) {
 	if nc == nil || nc.Pattern == NamingPatternNone {
 		return "", fmt.Errorf("naming pattern not configured")
@@ -126,12 +175,93 @@ func (nc *NamingConfig) FindNextVersion(existingVersions []string) (string, erro
 		}
 		return findNextSequential(existingVersions, true, padding)
 	case NamingPatternSemver:
-		return "", fmt.Errorf("auto-generation not supported for semver pattern, please specify version manually")
+		return NextSemver(existingVersions, BumpPatch)
+	case NamingPatternTimestamp:
+		return findNextTimestamp(existingVersions, time.Now())
 	default:
 		return "", fmt.Errorf("unknown naming pattern: %s", nc.Pattern)
 	}
 }
 
+// NextSemver returns the highest semantic version in existingVersions with
+// the given part incremented, or "1.0.0" if there is none. Versions that
+// are not semantic versions are ignored.
+func NextSemver(existingVersions []string, bump VersionBump) (string, error) {
+	var latest [3]int
+	found := false
+
+	for _, v := range existingVersions {
+		parsed, ok := parseSemver(v)
+		if !ok {
+			continue
+		}
+		if !found || compareSemver(parsed, latest) > 0 {
+			latest, found = parsed, true
+		}
+	}
+
+	if !found {
+		return "1.0.0", nil
+	}
+
+	switch bump {
+	case BumpMajor:
+		latest = [3]int{latest[0] + 1, 0, 0}
+	case BumpMinor:
+		latest = [3]int{latest[0], latest[1] + 1, 0}
+	case BumpPatch:
+		latest[2]++
+	default:
+		return "", fmt.Errorf("invalid version bump %q", bump)
+	}
+
+	return fmt.Sprintf("%d.%d.%d", latest[0], latest[1], latest[2]), nil
+}
+
+// parseSemver parses a MAJOR.MINOR.PATCH version.
+func parseSemver(version string) ([3]int, bool) {
+	var v [3]int
+	if validateSemver(version) != nil {
+		return v, false
+	}
+
+	for i, part := range strings.SplitN(version, ".", 3) {
+		n, err := strconv.Atoi(part)
+		if err != nil {
+			return v, false
+		}
+		v[i] = n
+	}
+	return v, true
+}
+
+func compareSemver(a, b [3]int) int {
+	for i := range a {
+		if a[i] != b[i] {
+			return a[i] - b[i]
+		}
+	}
+	return 0
+}
+
+// findNextTimestamp returns now as a timestamp version, moved forward past the
+// newest existing timestamp so that generated versions never collide.
+func findNextTimestamp(versions []string, now time.Time) (string, error) {
+	next := now.UTC().Truncate(time.Second)
+
+	for _, v := range versions {
+		t, err := time.Parse(TimestampLayout, v)
+		if err != nil || len(v) != len(TimestampLayout) {
+			continue
+		}
+		if !t.Before(next) {
+			next = t.Add(time.Second)
+		}
+	}
+
+	return next.Format(TimestampLayout), nil
+}
+
 // findNextSequential finds the next sequential version number.
 func findNextSequential(versions []string, padded bool, padding int) (string, error) {
 	maxVersion := 0
@@ -119,6 +119,32 @@ func TestNamingConfig_Validate(t *testing.T) {
 			wantErr: true,
 		},
 
+		// NamingPatternTimestamp
+		{
+			name:    "timestamp valid: 20261016153045",
+			config:  &NamingConfig{Pattern: NamingPatternTimestamp},
+			version: "20261016153045",
+			wantErr: false,
+		},
+		{
+			name:    "timestamp invalid: 2026101615 (too short)",
+			config:  &NamingConfig{Pattern: NamingPatternTimestamp},
+			version: "2026101615",
+			wantErr: true,
+		},
+		{
+			name:    "timestamp invalid: 20261316153045 (month 13)",
+			config:  &NamingConfig{Pattern: NamingPatternTimestamp},
+			version: "20261316153045",
+			wantErr: true,
+		},
+		{
+			name:    "timestamp invalid: 001",
+			config:  &NamingConfig{Pattern: NamingPatternTimestamp},
+			version: "001",
+			wantErr: true,
+		},
+
 		// Unknown pattern
 		{
 			name:    "unknown pattern",
@@ -201,11 +227,27 @@ func TestNamingConfig_FindNextVersion(t *testing.T) {
 
 		// Semver
 		{
-			name:     "semver: not supported",
+			name:     "semver: first version",
 			config:   &NamingConfig{Pattern: NamingPatternSemver},
 			existing: []string{},
-			want:     "",
-			wantErr:  true,
+			want:     "1.0.0",
+			wantErr:  false,
+		},
+		{
+			name:     "semver: bumps patch of highest",
+			config:   &NamingConfig{Pattern: NamingPatternSemver},
+			existing: []string{"1.9.0", "1.10.2", "1.2.7", "notes"},
+			want:     "1.10.3",
+			wantErr:  false,
+		},
+
+		// Timestamp
+		{
+			name:     "timestamp: after future version",
+			config:   &NamingConfig{Pattern: NamingPatternTimestamp},
+			existing: []string{"29991231235959"},
+			want:     "30000101000000",
+			wantErr:  false,
 		},
 
 		// No config
@@ -239,6 +281,66 @@ func TestNamingConfig_FindNextVersion(t *testing.T) {
 	}
 }
 
+func TestNextSemver(t *testing.T) {
+	existing := []string{"1.0.0", "1.4.2", "1.10.0", "0.9.9"}
+
+	tests := []struct {
+		bump VersionBump
+		want string
+	}{
+		{BumpMajor, "2.0.0"},
+		{BumpMinor, "1.11.0"},
+		{BumpPatch, "1.10.1"},
+	}
+
+	for _, tt := range tests {
+		got, err := NextSemver(existing, tt.bump)
+		if err != nil || got != tt.want {
+			t.Errorf("NextSemver(%s) = %q, %v; want %q", tt.bump, got, err, tt.want)
+		}
+	}
+
+	if _, err := NextSemver(existing, "huge"); err == nil {
+		t.Error("NextSemver() with invalid bump should fail")
+	}
+	if _, err := ParseVersionBump("Minor"); err != nil {
+		t.Errorf("ParseVersionBump(Minor) error = %v", err)
+	}
+	if _, err := ParseVersionBump("huge"); err == nil {
+		t.Error("ParseVersionBump(huge) should fail")
+	}
+}
+
+func TestFindNextTimestamp(t *testing.T) {
+	now := time.Date(2026, 10, 16, 15, 30, 45, 500, time.FixedZone("CEST", 2*3600))
+
+	tests := []struct {
+		name     string
+		existing []string
+		want     string
+	}{
+		{"no versions", nil, "20261016133045"},
+		{"older versions", []string{"20261016133044", "001"}, "20261016133045"},
+		{"same second", []string{"20261016133045"}, "20261016133046"},
+		{"clock behind", []string{"20261016133050", "20261016133047"}, "20261016133051"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := findNextTimestamp(tt.existing, now)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("findNextTimestamp() = %q, want %q", got, tt.want)
+			}
+			if err := validateTimestamp(got); err != nil {
+				t.Errorf("generated version is invalid: %v", err)
+			}
+		})
+	}
+}
+
 func TestQueen_Add_WithNamingValidation(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -0,0 +1,103 @@
+package queen
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// OutOfOrderPolicy controls how Queen handles pending migrations whose version
+// is older than the newest applied migration.
+//
+// Such gaps appear when feature branches with older versions are merged after
+// newer migrations were already deployed.
+type OutOfOrderPolicy string
+
+const (
+	// OutOfOrderAllow applies out-of-order migrations silently (default for backward compatibility).
+	OutOfOrderAllow OutOfOrderPolicy = "allow"
+
+	// OutOfOrderWarn applies out-of-order migrations and logs a warning for each of them.
+	OutOfOrderWarn OutOfOrderPolicy = "warn"
+
+	// OutOfOrderError refuses to apply out-of-order migrations and makes Validate fail.
+	// Recommended for CI to catch late-merged migrations before production.
+	OutOfOrderError OutOfOrderPolicy = "error"
+)
+
+// ParseOutOfOrderPolicy converts a string ("allow", "warn", "error") to an OutOfOrderPolicy.
+// An empty string returns OutOfOrderAllow.
+func ParseOutOfOrderPolicy(s string) (OutOfOrderPolicy, error) {
+	switch p := OutOfOrderPolicy(strings.ToLower(s)); p {
+	case "":
+		return OutOfOrderAllow, nil
+	case OutOfOrderAllow, OutOfOrderWarn, OutOfOrderError:
+		return p, nil
+	default:
+		return "", fmt.Errorf("invalid out-of-order policy %q (must be %s, %s or %s)",
+			s, OutOfOrderAllow, OutOfOrderWarn, OutOfOrderError)
+	}
+}
+
+// latestAppliedVersions returns the highest applied version of each group
+// (keyed by GroupOf, "" for ungrouped migrations). Versions in skip are ignored.
+// Requires loadApplied to be called first.
+func (q *Queen) latestAppliedVersions(skip []*Migration) map[string]string {
+	skipped := make(map[string]bool, len(skip))
+	for _, m := range skip {
+		skipped[m.Version] = true
+	}
+
+	latest := make(map[string]string)
+	for version := range q.applied {
+		if skipped[version] {
+			continue
+		}
+		group := GroupOf(version)
+		if current, ok := latest[group]; !ok || compareVersions(version, current) > 0 {
+			latest[group] = version
+		}
+	}
+	return latest
+}
+
+// isOutOfOrder reports whether a pending migration is older than the latest
+// applied version of its group. It returns that version as well.
+func isOutOfOrder(m *Migration, latest map[string]string) (string, bool) {
+	v, ok := latest[GroupOf(m.Version)]
+	return v, ok && compareVersions(m.Version, v) < 0
+}
+
+// checkOutOfOrder applies the configured OutOfOrderPolicy to migrations about to be applied.
+func (q *Queen) checkOutOfOrder(ctx context.Context, pending []*Migration, latest map[string]string) error {
+	if q.config.OutOfOrder == "" || q.config.OutOfOrder == OutOfOrderAllow {
+		return nil
+	}
+
+	var versions, newer []string
+	for _, m := range pending {
+		latestInGroup, outOfOrder := isOutOfOrder(m, latest)
+		if !outOfOrder {
+			continue
+		}
+		versions = append(versions, m.Version)
+		if !slices.Contains(newer, latestInGroup) {
+			newer = append(newer, latestInGroup)
+		}
+
+		if q.config.OutOfOrder == OutOfOrderWarn {
+			q.logger.WarnContext(ctx, "applying out-of-order migration",
+				"version", m.Version,
+				"name", m.Name,
+				"latest_applied", latestInGroup)
+		}
+	}
+
+	if len(versions) > 0 && q.config.OutOfOrder == OutOfOrderError {
+		return fmt.Errorf("%w: %s older than latest applied version %s",
+			ErrOutOfOrder, strings.Join(versions, ", "), strings.Join(newer, ", "))
+	}
+
+	return nil
+}
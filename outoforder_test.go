@@ -0,0 +1,143 @@
+package queen
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+// newGapQueen returns a Queen where "3" is applied and "2" is pending out of order.
+func newGapQueen(policy OutOfOrderPolicy) (*Queen, *memDriver) {
+	driver := newMemDriver()
+	driver.applied["1"] = Applied{Version: "1", Checksum: "v1"}
+	driver.applied["3"] = Applied{Version: "3", Checksum: "v1"}
+
+	q := NewWithConfig(driver, &Config{OutOfOrder: policy})
+	for _, v := range []string{"1", "2", "3", "4"} {
+		q.MustAdd(noopMigration(v, "m"+v))
+	}
+	return q, driver
+}
+
+func TestOutOfOrderPolicy(t *testing.T) {
+	tests := []struct {
+		policy  OutOfOrderPolicy
+		wantErr bool
+	}{
+		{"", false},
+		{OutOfOrderAllow, false},
+		{OutOfOrderWarn, false},
+		{OutOfOrderError, true},
+	}
+
+	for _, tt := range tests {
+		t.Run("up/"+string(tt.policy), func(t *testing.T) {
+			q, driver := newGapQueen(tt.policy)
+
+			err := q.Up(context.Background())
+			if tt.wantErr {
+				if !errors.Is(err, ErrOutOfOrder) {
+					t.Fatalf("expected ErrOutOfOrder, got %v", err)
+				}
+				if len(driver.applied) != 2 {
+					t.Errorf("nothing should be applied, got %v", driver.appliedVersions())
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Up failed: %v", err)
+			}
+			if len(driver.applied) != 4 {
+				t.Errorf("applied = %v, want all 4", driver.appliedVersions())
+			}
+		})
+
+		t.Run("validate/"+string(tt.policy), func(t *testing.T) {
+			q, _ := newGapQueen(tt.policy)
+
+			err := q.Validate(context.Background())
+			if tt.wantErr != errors.Is(err, ErrOutOfOrder) {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	t.Run("in-order pending migrations pass strict mode", func(t *testing.T) {
+		q, driver := newGapQueen(OutOfOrderError)
+		driver.applied["2"] = Applied{Version: "2", Checksum: "v1"}
+
+		if err := q.Validate(context.Background()); err != nil {
+			t.Fatalf("Validate failed: %v", err)
+		}
+		if err := q.Up(context.Background()); err != nil {
+			t.Fatalf("Up failed: %v", err)
+		}
+	})
+
+	t.Run("MigrateTo ignores versions it rolls back", func(t *testing.T) {
+		q, driver := newGapQueen(OutOfOrderError)
+
+		// Rolling back 3 makes 2 the next in-order migration
+		if err := q.MigrateTo(context.Background(), "2"); err != nil {
+			t.Fatalf("MigrateTo failed: %v", err)
+		}
+		if len(driver.applied) != 2 {
+			t.Errorf("applied = %v, want [1 2]", driver.appliedVersions())
+		}
+	})
+}
+
+func TestOutOfOrderStatus(t *testing.T) {
+	q, _ := newGapQueen(OutOfOrderAllow)
+
+	statuses, err := q.Status(context.Background())
+	if err != nil {
+		t.Fatalf("Status failed: %v", err)
+	}
+
+	want := map[string]Status{
+		"1": StatusApplied,
+		"2": StatusOutOfOrder,
+		"3": StatusApplied,
+		"4": StatusPending,
+	}
+	for _, s := range statuses {
+		if s.Status != want[s.Version] {
+			t.Errorf("version %s status = %s, want %s", s.Version, s.Status, want[s.Version])
+		}
+	}
+
+	plans, err := q.DryRun(context.Background(), "up", 0)
+	if err != nil {
+		t.Fatalf("DryRun failed: %v", err)
+	}
+	if len(plans) != 2 || plans[0].Status != "out-of-order" || plans[1].Status != "pending" {
+		t.Errorf("unexpected plan statuses: %+v", plans)
+	}
+	if len(plans[0].Warnings) == 0 {
+		t.Error("out-of-order plan should have a warning")
+	}
+}
+
+func TestParseOutOfOrderPolicy(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    OutOfOrderPolicy
+		wantErr bool
+	}{
+		{"", OutOfOrderAllow, false},
+		{"allow", OutOfOrderAllow, false},
+		{"WARN", OutOfOrderWarn, false},
+		{"error", OutOfOrderError, false},
+		{"strict", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := ParseOutOfOrderPolicy(tt.in)
+			if (err != nil) != tt.wantErr || got != tt.want {
+				t.Errorf("ParseOutOfOrderPolicy(%q) = %q, %v", tt.in, got, err)
+			}
+		})
+	}
+}
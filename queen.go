@@ -107,6 +107,8 @@
 //	q.UpSteps(ctx, 3)      // Apply next 3 migrations
 //	q.Down(ctx, 1)         // Rollback last migration
 //	q.Reset(ctx)           // Rollback all migrations
+//	q.MigrateTo(ctx, "042") // Apply or rollback to exactly version 042
+//	q.Baseline(ctx, "042")  // Mark 001..042 as applied without running them
 //	statuses, _ := q.Status(ctx)  // Get migration status
 //	q.Validate(ctx)        // Validate migrations
 package queen
@@ -118,8 +120,6 @@ import (
 	"sort"
 	"strings"
 	"time"
-
-	naturalsort "github.com/honeynil/queen/internal/sort"
 )
 
 // Queen manages database migrations.
@@ -128,9 +128,13 @@ type Queen struct {
 	migrations []*Migration
 	config     *Config
 	logger     Logger
+	hooks      hooks
 
 	// Track which migrations have been applied (cache)
 	applied map[string]*Applied
+
+	// Migrations interrupted mid-flight (cache, see DirtyTracker)
+	dirty map[string]*DirtyMigration
 }
 
 // Config configures Queen behavior.
@@ -160,6 +164,28 @@ type Config struct {
 	//
 	// Individual migrations can override this with their own IsolationLevel.
 	IsolationLevel sql.IsolationLevel
+
+	// OutOfOrder controls pending migrations older than the newest applied one.
+	// Default: OutOfOrderAllow (apply silently, for backward compatibility)
+	//
+	// With OutOfOrderError, Up refuses to apply them and Validate fails.
+	OutOfOrder OutOfOrderPolicy
+
+	// Group restricts Up, Down, Reset, Status and orphan detection to the
+	// migrations of one group (see Migration.Group). Default: "" (all groups)
+	Group string
+
+	// FailOnOrphaned makes Validate fail when the database contains applied
+	// migrations that are no longer registered. Default: false (logged as a warning)
+	FailOnOrphaned bool
+
+	// AppliedBy is stored in the history table as the identity applying migrations.
+	// Default: the current OS user
+	AppliedBy string
+
+	// BuildTag is an application build identifier (e.g. git SHA or release tag)
+	// stored in the history table with each applied migration. Default: empty
+	BuildTag string
 }
 
 // DefaultConfig returns default settings: "queen_migrations" table, 30min lock timeout.
@@ -236,21 +262,26 @@ func NewWithConfig(driver Driver, config *Config) *Queen {
 }
 
 // Add registers a migration after validation.
-// Returns ErrVersionConflict if version already exists.
+// Returns ErrVersionConflict if version already exists in the migration's group.
 func (q *Queen) Add(m M) error {
 	if err := m.Validate(); err != nil {
 		return err
 	}
 
+	if err := qualifyGroup(&m); err != nil {
+		return err
+	}
+
 	for _, existing := range q.migrations {
 		if existing.Version == m.Version {
 			return fmt.Errorf("%w: %s", ErrVersionConflict, m.Version)
 		}
 	}
 
-	// Validate naming pattern if configured
+	// Validate naming pattern if configured, within the group
 	if q.config.Naming != nil {
-		if err := q.config.Naming.Validate(m.Version); err != nil {
+		_, version := splitGroup(m.Version)
+		if err := q.config.Naming.Validate(version); err != nil {
 			if q.config.Naming.Enforce {
 				return fmt.Errorf("naming pattern validation failed: %w", err)
 			}
@@ -309,12 +340,20 @@ func (q *Queen) UpSteps(ctx context.Context, n int) error {
 			_ = q.driver.Unlock(context.Background())
 			q.logger.InfoContext(context.Background(), "lock released", "table", q.config.TableName)
 		}()
+
+		lockCtx, stopWatch := q.watchLock(ctx)
+		defer stopWatch()
+		ctx = lockCtx
 	}
 
 	if err := q.loadApplied(ctx); err != nil {
 		return err
 	}
 
+	if err := q.checkDirty(); err != nil {
+		return err
+	}
+
 	pending := q.getPending()
 	if len(pending) == 0 {
 		return nil
@@ -324,13 +363,18 @@ func (q *Queen) UpSteps(ctx context.Context, n int) error {
 		pending = pending[:n]
 	}
 
-	for _, m := range pending {
-		if err := q.applyMigration(ctx, m); err != nil {
-			return newMigrationError(m.Version, m.Name, "up", q.getDriverName(), err)
-		}
+	if err := q.checkOutOfOrder(ctx, pending, q.latestAppliedVersions(nil)); err != nil {
+		return err
 	}
 
-	return nil
+	return q.withRunHooks(ctx, "up", func() error {
+		for _, m := range pending {
+			if err := q.applyMigration(ctx, m); err != nil {
+				return newMigrationError(m.Version, m.Name, "up", q.getDriverName(), err)
+			}
+		}
+		return nil
+	})
 }
 
 // Down rolls back the last n migrations.
@@ -355,12 +399,20 @@ func (q *Queen) Down(ctx context.Context, n int) error {
 		defer func() {
 			_ = q.driver.Unlock(context.Background())
 		}()
+
+		lockCtx, stopWatch := q.watchLock(ctx)
+		defer stopWatch()
+		ctx = lockCtx
 	}
 
 	if err := q.loadApplied(ctx); err != nil {
 		return err
 	}
 
+	if err := q.checkDirty(); err != nil {
+		return err
+	}
+
 	applied := q.getAppliedMigrations()
 	if len(applied) == 0 {
 		return nil
@@ -372,7 +424,57 @@ func (q *Queen) Down(ctx context.Context, n int) error {
 
 	toRollback := applied[:n]
 
-	for _, m := range toRollback {
+	return q.withRunHooks(ctx, "down", func() error {
+		return q.rollbackAll(ctx, toRollback)
+	})
+}
+
+// Reset rolls back all applied migrations.
+func (q *Queen) Reset(ctx context.Context) error {
+	if q.driver == nil {
+		return ErrNoDriver
+	}
+
+	if err := q.driver.Init(ctx); err != nil {
+		return err
+	}
+
+	if !q.config.SkipLock {
+		if err := q.driver.Lock(ctx, q.config.LockTimeout); err != nil {
+			return err
+		}
+		defer func() {
+			_ = q.driver.Unlock(context.Background())
+		}()
+
+		lockCtx, stopWatch := q.watchLock(ctx)
+		defer stopWatch()
+		ctx = lockCtx
+	}
+
+	if err := q.loadApplied(ctx); err != nil {
+		return err
+	}
+
+	if err := q.checkDirty(); err != nil {
+		return err
+	}
+
+	applied := q.getAppliedMigrations()
+	if len(applied) == 0 {
+		return nil
+	}
+
+	// Don't call Down() to avoid double-locking
+	return q.withRunHooks(ctx, "down", func() error {
+		return q.rollbackAll(ctx, applied)
+	})
+}
+
+// rollbackAll rolls back migrations in the given order,
+// stopping at the first one without a down migration.
+func (q *Queen) rollbackAll(ctx context.Context, migrations []*Migration) error {
+	for _, m := range migrations {
 		if !m.HasRollback() {
 			return newMigrationError(m.Version, m.Name, "down", q.getDriverName(), fmt.Errorf("no down migration defined"))
 		}
@@ -385,12 +487,25 @@ func (q *Queen) Down(ctx context.Context, n int) error {
 	return nil
 }
 
-// Reset rolls back all applied migrations.
-func (q *Queen) Reset(ctx context.Context) error {
+// MigrateTo brings the database to exactly the given version.
+//
+// Applied migrations newer than version are rolled back (newest first), then
+// pending migrations up to and including version are applied. Versions are
+// compared with natural sort, the same order used by Up and Down. For a
+// grouped version such as "billing/005" only the billing group is changed.
+//
+// The version must belong to a registered migration, otherwise
+// ErrMigrationNotFound is returned. Nothing happens if the database
+// is already at the target version.
+func (q *Queen) MigrateTo(ctx context.Context, version string) error {
 	if q.driver == nil {
 		return ErrNoDriver
 	}
 
+	if _, ok := q.findMigration(version); !ok {
+		return fmt.Errorf("%w: %s", ErrMigrationNotFound, version)
+	}
+
 	if err := q.driver.Init(ctx); err != nil {
 		return err
 	}
@@ -402,32 +517,120 @@ func (q *Queen) Reset(ctx context.Context) error {
 		defer func() {
 			_ = q.driver.Unlock(context.Background())
 		}()
+
+		lockCtx, stopWatch := q.watchLock(ctx)
+		defer stopWatch()
+		ctx = lockCtx
 	}
 
 	if err := q.loadApplied(ctx); err != nil {
 		return err
 	}
 
-	applied := q.getAppliedMigrations()
-	if len(applied) == 0 {
-		return nil
+	if err := q.checkDirty(); err != nil {
+		return err
 	}
 
-	// Don't call Down() to avoid double-locking
-	for _, m := range applied {
+	toRollback, toApply := q.planMigrateTo(version)
+
+	if err := q.checkOutOfOrder(ctx, toApply, q.latestAppliedVersions(toRollback)); err != nil {
+		return err
+	}
+
+	// Check all rollbacks upfront so nothing is rolled back halfway to an unreachable target
+	for _, m := range toRollback {
 		if !m.HasRollback() {
 			return newMigrationError(m.Version, m.Name, "down", q.getDriverName(), fmt.Errorf("no down migration defined"))
 		}
+	}
 
-		if err := q.rollbackMigration(ctx, m); err != nil {
-			return newMigrationError(m.Version, m.Name, "down", q.getDriverName(), err)
+	if len(toRollback) == 0 && len(toApply) == 0 {
+		return nil
+	}
+
+	return q.withRunHooks(ctx, "goto", func() error {
+		if err := q.rollbackAll(ctx, toRollback); err != nil {
+			return err
+		}
+
+		for _, m := range toApply {
+			if err := q.applyMigration(ctx, m); err != nil {
+				return newMigrationError(m.Version, m.Name, "up", q.getDriverName(), err)
+			}
+		}
+
+		return nil
+	})
+}
+
+// planMigrateTo returns applied migrations newer than version (newest first)
+// and pending migrations up to and including version (oldest first).
+// Only migrations of the version's group are considered.
+// Requires loadApplied to be called first.
+func (q *Queen) planMigrateTo(version string) (toRollback, toApply []*Migration) {
+	group := GroupOf(version)
+
+	for _, m := range q.getAppliedMigrations() {
+		if GroupOf(m.Version) == group && compareVersions(m.Version, version) > 0 {
+			toRollback = append(toRollback, m)
 		}
 	}
 
-	return nil
+	for _, m := range q.getPending() {
+		if GroupOf(m.Version) == group && compareVersions(m.Version, version) <= 0 {
+			toApply = append(toApply, m)
+		}
+	}
+
+	return toRollback, toApply
+}
+
+// Baseline marks all registered migrations up to and including version as
+// applied without executing them, and returns the migrations it recorded.
+//
+// Use it when adopting Queen on an existing database whose schema already
+// matches those migrations. Already applied migrations are skipped, so
+// Baseline is safe to repeat. Migrations newer than version and migrations
+// of other groups stay pending.
+//
+// The version must belong to a registered migration, otherwise
+// ErrMigrationNotFound is returned.
+func (q *Queen) Baseline(ctx context.Context, version string) ([]*Migration, error) {
+	if q.driver == nil {
+		return nil, ErrNoDriver
+	}
+
+	if _, ok := q.findMigration(version); !ok {
+		return nil, fmt.Errorf("%w: %s", ErrMigrationNotFound, version)
+	}
+
+	var recorded []*Migration
+	err := q.withLock(ctx, func() error {
+		for _, m := range q.getPending() {
+			if GroupOf(m.Version) != GroupOf(version) || compareVersions(m.Version, version) > 0 {
+				continue
+			}
+
+			if err := q.recordOnly(ctx, m, "baseline"); err != nil {
+				return newMigrationError(m.Version, m.Name, "baseline", q.getDriverName(), err)
+			}
+			recorded = append(recorded, m)
+		}
+		return nil
+	})
+	if err != nil {
+		return recorded, err
+	}
+
+	q.logger.InfoContext(ctx, "baseline completed",
+		"version", version,
+		"recorded", len(recorded))
+
+	return recorded, nil
 }
 
-// Status returns the status of all registered migrations.
+// Status returns the status of all registered migrations, followed by
+// applied migrations that are no longer registered (StatusOrphaned).
 func (q *Queen) Status(ctx context.Context) ([]MigrationStatus, error) {
 	if q.driver == nil {
 		return nil, ErrNoDriver
@@ -441,11 +644,18 @@ func (q *Queen) Status(ctx context.Context) ([]MigrationStatus, error) {
 		return nil, err
 	}
 
-	statuses := make([]MigrationStatus, len(q.migrations))
-	for i, m := range q.migrations {
+	latest := q.latestAppliedVersions(nil)
+
+	statuses := make([]MigrationStatus, 0, len(q.migrations))
+	for _, m := range q.migrations {
+		if !q.inGroup(m.Version) {
+			continue
+		}
+
 		status := MigrationStatus{
 			Version:     m.Version,
 			Name:        m.Name,
+			Group:       m.Group,
 			Checksum:    m.Checksum(),
 			HasRollback: m.HasRollback(),
 			Destructive: m.IsDestructive(),
@@ -460,9 +670,27 @@ func (q *Queen) Status(ctx context.Context) ([]MigrationStatus, error) {
 			if applied.Checksum != m.Checksum() && m.Checksum() != noChecksumMarker {
 				status.Status = StatusModified
 			}
+		} else if _, outOfOrder := isOutOfOrder(m, latest); outOfOrder {
+			status.Status = StatusOutOfOrder
 		}
 
-		statuses[i] = status
+		if _, dirty := q.dirty[m.Version]; dirty {
+			status.Status = StatusDirty
+		}
+
+		statuses = append(statuses, status)
+	}
+
+	// Applied migrations that were removed from code are reported after registered ones
+	for _, applied := range q.getOrphaned() {
+		statuses = append(statuses, MigrationStatus{
+			Version:   applied.Version,
+			Name:      applied.Name,
+			Group:     GroupOf(applied.Version),
+			Checksum:  applied.Checksum,
+			AppliedAt: &applied.AppliedAt,
+			Status:    StatusOrphaned,
+		})
 	}
 
 	return statuses, nil
@@ -509,6 +737,21 @@ func (q *Queen) Validate(ctx context.Context) error {
 				}
 			}
 		}
+
+		for _, applied := range q.getOrphaned() {
+			if q.config.FailOnOrphaned {
+				return fmt.Errorf("%w: %s (%s)", ErrOrphanedMigration, applied.Version, applied.Name)
+			}
+			q.logger.WarnContext(ctx, "applied migration is not registered",
+				"version", applied.Version,
+				"name", applied.Name)
+		}
+
+		if q.config.OutOfOrder == OutOfOrderError {
+			if err := q.checkOutOfOrder(ctx, q.getPending(), q.latestAppliedVersions(nil)); err != nil {
+				return err
+			}
+		}
 	}
 
 	return nil
@@ -668,45 +911,110 @@ func (q *Queen) loadApplied(ctx context.Context) error {
 		q.applied[applied[i].Version] = &applied[i]
 	}
 
-	return nil
+	return q.loadDirty(ctx)
 }
 
-// getPending returns unapplied migrations sorted by version.
+// getPending returns unapplied migrations of the selected group sorted by
+// group, then by version.
 func (q *Queen) getPending() []*Migration {
 	pending := make([]*Migration, 0)
 
 	for _, m := range q.migrations {
-		if _, applied := q.applied[m.Version]; !applied {
+		if _, applied := q.applied[m.Version]; !applied && q.inGroup(m.Version) {
 			pending = append(pending, m)
 		}
 	}
 
-	// Sort by version using natural sort
+	// Sort by group, then by version using natural sort
 	sort.Slice(pending, func(i, j int) bool {
-		return naturalsort.Compare(pending[i].Version, pending[j].Version) < 0
+		return compareVersions(pending[i].Version, pending[j].Version) < 0
 	})
 
 	return pending
 }
 
-// getAppliedMigrations returns applied migrations sorted newest-first.
+// getAppliedMigrations returns applied migrations of the selected group
+// in the reverse order of getPending (newest first).
 func (q *Queen) getAppliedMigrations() []*Migration {
 	applied := make([]*Migration, 0)
 
 	for _, m := range q.migrations {
-		if _, ok := q.applied[m.Version]; ok {
+		if _, ok := q.applied[m.Version]; ok && q.inGroup(m.Version) {
 			applied = append(applied, m)
 		}
 	}
 
-	// Sort by version using natural sort, then reverse
+	// Sort by group, then by version using natural sort, then reverse
 	sort.Slice(applied, func(i, j int) bool {
-		return naturalsort.Compare(applied[i].Version, applied[j].Version) > 0
+		return compareVersions(applied[i].Version, applied[j].Version) > 0
 	})
 
 	return applied
 }
 
+// getOrphaned returns applied migrations of the selected group that are not
+// registered, sorted by group and version.
+func (q *Queen) getOrphaned() []*Applied {
+	registered := make(map[string]bool, len(q.migrations))
+	for _, m := range q.migrations {
+		registered[m.Version] = true
+	}
+
+	orphaned := make([]*Applied, 0)
+	for version, applied := range q.applied {
+		if !registered[version] && q.inGroup(version) {
+			orphaned = append(orphaned, applied)
+		}
+	}
+
+	sort.Slice(orphaned, func(i, j int) bool {
+		return compareVersions(orphaned[i].Version, orphaned[j].Version) < 0
+	})
+
+	return orphaned
+}
+
+// findMigration returns the registered migration with the given version.
+func (q *Queen) findMigration(version string) (*Migration, bool) {
+	for _, m := range q.migrations {
+		if m.Version == version {
+			return m, true
+		}
+	}
+	return nil, false
+}
+
+// SupportsTransactionalDDL reports whether the driver records migrations
+// atomically inside the migration transaction.
+//
+// It returns false for drivers that don't implement TxRecorder or whose
+// database auto-commits DDL (MySQL, ClickHouse, YDB). For those drivers
+// the tracking row is written in a separate step after the migration commits.
+func (q *Queen) SupportsTransactionalDDL() bool {
+	_, ok := q.txRecorder()
+	return ok
+}
+
+// execNoTx runs fn on a dedicated connection without a transaction.
+func (q *Queen) execNoTx(ctx context.Context, fn func(context.Context, *sql.Conn) error) error {
+	executor, ok := q.driver.(NoTxExecutor)
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrNoTxUnsupported, q.getDriverName())
+	}
+	return executor.ExecNoTx(ctx, func(conn *sql.Conn) error {
+		return fn(ctx, conn)
+	})
+}
+
+// txRecorder returns the driver as a TxRecorder if it supports transactional DDL.
+func (q *Queen) txRecorder() (TxRecorder, bool) {
+	recorder, ok := q.driver.(TxRecorder)
+	if !ok || !recorder.SupportsTransactionalDDL() {
+		return nil, false
+	}
+	return recorder, true
+}
+
 // getIsolationLevel returns the effective isolation level for a migration.
 // Priority: Migration.IsolationLevel -> Config.IsolationLevel -> LevelDefault
 func (q *Queen) getIsolationLevel(m *Migration) sql.IsolationLevel {
@@ -732,24 +1040,50 @@ func (q *Queen) applyMigration(ctx context.Context, m *Migration) error {
 	if isolationLevel != sql.LevelDefault {
 		logArgs = append(logArgs, "isolation_level", isolationLevel.String())
 	}
+	recorder, atomic := q.txRecorder()
+	splitter, _ := q.driver.(StatementSplitter)
+	if m.NoTransaction {
+		atomic = false
+		logArgs = append(logArgs, "no_transaction", true)
+	} else if !atomic {
+		logArgs = append(logArgs, "transactional_ddl", false)
+	}
 	q.logger.InfoContext(ctx, "migration started", logArgs...)
 
-	// Execute migration in transaction with specified isolation level
-	err := q.driver.Exec(ctx, isolationLevel, func(tx *sql.Tx) error {
-		return m.executeUp(ctx, tx)
-	})
-	if err != nil {
-		q.logger.ErrorContext(ctx, "migration failed",
-			"version", m.Version,
-			"name", m.Name,
-			"direction", "up",
-			"error", err,
-			"duration_ms", time.Since(start).Milliseconds())
-		return err
+	// Without atomic recording a crash can leave the migration half-applied
+	if !atomic {
+		if err := q.markDirty(ctx, m, "up"); err != nil {
+			return err
+		}
 	}
 
-	// Record in database
-	if err := q.driver.Record(ctx, m); err != nil {
+	// Execute migration in transaction with specified isolation level.
+	// With transactional DDL the tracking row is written in the same transaction.
+	// NoTransaction migrations run directly on a dedicated connection.
+	var err error
+	if m.NoTransaction {
+		err = q.execNoTx(ctx, func(ctx context.Context, conn *sql.Conn) error {
+			return q.withMigrationHooks(ctx, m, "up", nil, start, func() error {
+				return m.executeUpConn(ctx, conn, splitter)
+			})
+		})
+	} else {
+		err = q.driver.Exec(ctx, isolationLevel, func(tx *sql.Tx) error {
+			err := q.withMigrationHooks(ctx, m, "up", tx, start, func() error {
+				return m.executeUp(ctx, tx, splitter)
+			})
+			if err != nil {
+				return err
+			}
+			if atomic {
+				return recorder.RecordTx(ContextWithRecordInfo(ctx, q.recordInfo(start)), tx, m)
+			}
+			return nil
+		})
+	}
+	if err != nil {
+		q.onMigrationError(ctx, m, "up", start, err)
+		q.audit(ctx, m, "up", start, err)
 		q.logger.ErrorContext(ctx, "migration failed",
 			"version", m.Version,
 			"name", m.Name,
@@ -759,14 +1093,29 @@ func (q *Queen) applyMigration(ctx context.Context, m *Migration) error {
 		return err
 	}
 
-	// Update cache
-	q.applied[m.Version] = &Applied{
-		Version:   m.Version,
-		Name:      m.Name,
-		AppliedAt: time.Now(),
-		Checksum:  m.Checksum(),
+	info := q.recordInfo(start)
+
+	// Record in database (two-step mode for non-transactional DDL)
+	if !atomic {
+		if err := q.driver.Record(ContextWithRecordInfo(ctx, info), m); err != nil {
+			q.onMigrationError(ctx, m, "up", start, err)
+			q.audit(ctx, m, "up", start, err)
+			q.logger.ErrorContext(ctx, "migration failed",
+				"version", m.Version,
+				"name", m.Name,
+				"direction", "up",
+				"error", err,
+				"duration_ms", time.Since(start).Milliseconds())
+			return err
+		}
+		if err := q.clearDirty(ctx, m.Version); err != nil {
+			return err
+		}
 	}
 
+	q.cacheApplied(m, info)
+	q.audit(ctx, m, "up", start, nil)
+
 	q.logger.InfoContext(ctx, "migration completed",
 		"version", m.Version,
 		"name", m.Name,
@@ -776,6 +1125,43 @@ func (q *Queen) applyMigration(ctx context.Context, m *Migration) error {
 	return nil
 }
 
+// recordOnly records m as applied without executing it.
+// operation names the action in the audit log (e.g. "baseline").
+func (q *Queen) recordOnly(ctx context.Context, m *Migration, operation string) error {
+	start := time.Now()
+	info := q.recordInfo(start)
+
+	if err := q.driver.Record(ContextWithRecordInfo(ctx, info), m); err != nil {
+		q.audit(ctx, m, operation, start, err)
+		return err
+	}
+
+	q.cacheApplied(m, info)
+	q.audit(ctx, m, operation, start, nil)
+
+	q.logger.InfoContext(ctx, "migration recorded without execution",
+		"version", m.Version,
+		"name", m.Name,
+		"operation", operation)
+
+	return nil
+}
+
+// cacheApplied adds m to the applied migrations cache.
+func (q *Queen) cacheApplied(m *Migration, info RecordInfo) {
+	q.applied[m.Version] = &Applied{
+		Version:      m.Version,
+		Name:         m.Name,
+		AppliedAt:    time.Now(),
+		Checksum:     m.Checksum(),
+		Duration:     info.Duration,
+		AppliedBy:    info.AppliedBy,
+		Hostname:     info.Hostname,
+		QueenVersion: info.QueenVersion,
+		BuildTag:     info.BuildTag,
+	}
+}
+
 // rollbackMigration rolls back a single migration.
 func (q *Queen) rollbackMigration(ctx context.Context, m *Migration) error {
 	start := time.Now()
@@ -789,13 +1175,50 @@ func (q *Queen) rollbackMigration(ctx context.Context, m *Migration) error {
 	if isolationLevel != sql.LevelDefault {
 		logArgs = append(logArgs, "isolation_level", isolationLevel.String())
 	}
+	recorder, atomic := q.txRecorder()
+	splitter, _ := q.driver.(StatementSplitter)
+	if m.NoTransaction {
+		atomic = false
+		logArgs = append(logArgs, "no_transaction", true)
+	} else if !atomic {
+		logArgs = append(logArgs, "transactional_ddl", false)
+	}
 	q.logger.InfoContext(ctx, "migration started", logArgs...)
 
-	// Execute rollback in transaction with specified isolation level
-	err := q.driver.Exec(ctx, isolationLevel, func(tx *sql.Tx) error {
-		return m.executeDown(ctx, tx)
-	})
+	// Without atomic recording a crash can leave the rollback half-done
+	if !atomic {
+		if err := q.markDirty(ctx, m, "down"); err != nil {
+			return err
+		}
+	}
+
+	// Execute rollback in transaction with specified isolation level.
+	// With transactional DDL the tracking row is removed in the same transaction.
+	// NoTransaction migrations run directly on a dedicated connection.
+	var err error
+	if m.NoTransaction {
+		err = q.execNoTx(ctx, func(ctx context.Context, conn *sql.Conn) error {
+			return q.withMigrationHooks(ctx, m, "down", nil, start, func() error {
+				return m.executeDownConn(ctx, conn, splitter)
+			})
+		})
+	} else {
+		err = q.driver.Exec(ctx, isolationLevel, func(tx *sql.Tx) error {
+			err := q.withMigrationHooks(ctx, m, "down", tx, start, func() error {
+				return m.executeDown(ctx, tx, splitter)
+			})
+			if err != nil {
+				return err
+			}
+			if atomic {
+				return recorder.RemoveTx(ctx, tx, m.Version)
+			}
+			return nil
+		})
+	}
 	if err != nil {
+		q.onMigrationError(ctx, m, "down", start, err)
+		q.audit(ctx, m, "down", start, err)
 		q.logger.ErrorContext(ctx, "migration failed",
 			"version", m.Version,
 			"name", m.Name,
@@ -805,20 +1228,29 @@ func (q *Queen) rollbackMigration(ctx context.Context, m *Migration) error {
 		return err
 	}
 
-	// Remove from database
-	if err := q.driver.Remove(ctx, m.Version); err != nil {
-		q.logger.ErrorContext(ctx, "migration failed",
-			"version", m.Version,
-			"name", m.Name,
-			"direction", "down",
-			"error", err,
-			"duration_ms", time.Since(start).Milliseconds())
-		return err
+	// Remove from database (two-step mode for non-transactional DDL)
+	if !atomic {
+		if err := q.driver.Remove(ctx, m.Version); err != nil {
+			q.onMigrationError(ctx, m, "down", start, err)
+			q.audit(ctx, m, "down", start, err)
+			q.logger.ErrorContext(ctx, "migration failed",
+				"version", m.Version,
+				"name", m.Name,
+				"direction", "down",
+				"error", err,
+				"duration_ms", time.Since(start).Milliseconds())
+			return err
+		}
+		if err := q.clearDirty(ctx, m.Version); err != nil {
+			return err
+		}
 	}
 
 	// Update cache
 	delete(q.applied, m.Version)
 
+	q.audit(ctx, m, "down", start, nil)
+
 	q.logger.InfoContext(ctx, "migration completed",
 		"version", m.Version,
 		"name", m.Name,
@@ -837,6 +1269,7 @@ func (q *Queen) createMigrationPlan(m *Migration, direction string) MigrationPla
 		HasRollback:   m.HasRollback(),
 		IsDestructive: false,
 		Checksum:      m.Checksum(),
+		NoTransaction: m.NoTransaction,
 		Warnings:      make([]string, 0),
 	}
 
@@ -853,6 +1286,9 @@ func (q *Queen) createMigrationPlan(m *Migration, direction string) MigrationPla
 				"expected_checksum", applied.Checksum,
 				"actual_checksum", m.Checksum())
 		}
+	} else if latest, outOfOrder := isOutOfOrder(m, q.latestAppliedVersions(nil)); outOfOrder {
+		plan.Status = StatusOutOfOrder.String()
+		plan.Warnings = append(plan.Warnings, fmt.Sprintf("Out of order - older than latest applied migration %s", latest))
 	} else {
 		plan.Status = "pending"
 	}
@@ -867,7 +1303,7 @@ func (q *Queen) createMigrationPlan(m *Migration, direction string) MigrationPla
 			hasSQL = true
 			sql = m.UpSQL
 		}
-		if m.UpFunc != nil {
+		if m.UpFunc != nil || m.UpConnFunc != nil {
 			hasFunc = true
 		}
 	} else {
@@ -875,7 +1311,7 @@ func (q *Queen) createMigrationPlan(m *Migration, direction string) MigrationPla
 			hasSQL = true
 			sql = m.DownSQL
 		}
-		if m.DownFunc != nil {
+		if m.DownFunc != nil || m.DownConnFunc != nil {
 			hasFunc = true
 		}
 		// Check for destructive operations in down migrations
@@ -908,5 +1344,9 @@ func (q *Queen) createMigrationPlan(m *Migration, direction string) MigrationPla
 		plan.Warnings = append(plan.Warnings, "Destructive operation")
 	}
 
+	if m.NoTransaction {
+		plan.Warnings = append(plan.Warnings, "Runs outside a transaction - partial changes are not rolled back on failure")
+	}
+
 	return plan
 }
@@ -3,8 +3,15 @@ package queen
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"sort"
+	"strings"
 	"testing"
 	"time"
+
+	naturalsort "github.com/honeynil/queen/internal/sort"
 )
 
 // testDriver is a minimal driver implementation for testing.
@@ -39,3 +46,434 @@ func TestGetDriverNameNilDriver(t *testing.T) {
 		t.Errorf("getDriverName() with nil driver = %q, want %q", driverName, "unknown")
 	}
 }
+
+// txTestDriver records which tracking methods were used.
+// Exec invokes fn with a nil transaction, which is enough for Go function migrations.
+type txTestDriver struct {
+	testDriver
+	transactional bool
+	recordTxErr   error
+
+	recorded   []string
+	recordedTx []string
+	removed    []string
+	removedTx  []string
+}
+
+func (d *txTestDriver) GetApplied(ctx context.Context) ([]Applied, error) {
+	applied := make([]Applied, 0)
+	for _, v := range append(d.recorded, d.recordedTx...) {
+		applied = append(applied, Applied{Version: v, Checksum: "v1"})
+	}
+	return applied, nil
+}
+func (d *txTestDriver) Exec(ctx context.Context, isolationLevel sql.IsolationLevel, fn func(*sql.Tx) error) error {
+	return fn(nil)
+}
+func (d *txTestDriver) Record(ctx context.Context, m *Migration) error {
+	d.recorded = append(d.recorded, m.Version)
+	return nil
+}
+func (d *txTestDriver) Remove(ctx context.Context, version string) error {
+	d.removed = append(d.removed, version)
+	return nil
+}
+func (d *txTestDriver) SupportsTransactionalDDL() bool { return d.transactional }
+func (d *txTestDriver) RecordTx(ctx context.Context, tx *sql.Tx, m *Migration) error {
+	if d.recordTxErr != nil {
+		return d.recordTxErr
+	}
+	d.recordedTx = append(d.recordedTx, m.Version)
+	return nil
+}
+func (d *txTestDriver) RemoveTx(ctx context.Context, tx *sql.Tx, version string) error {
+	d.removedTx = append(d.removedTx, version)
+	return nil
+}
+
+func noopMigration(version, name string) M {
+	return M{
+		Version:        version,
+		Name:           name,
+		ManualChecksum: "v1",
+		UpFunc:         func(ctx context.Context, tx *sql.Tx) error { return nil },
+		DownFunc:       func(ctx context.Context, tx *sql.Tx) error { return nil },
+	}
+}
+
+func TestTransactionalRecording(t *testing.T) {
+	t.Run("transactional DDL records inside transaction", func(t *testing.T) {
+		driver := &txTestDriver{transactional: true}
+		q := New(driver)
+		q.MustAdd(noopMigration("001", "first"))
+
+		if !q.SupportsTransactionalDDL() {
+			t.Fatal("SupportsTransactionalDDL() = false, want true")
+		}
+		if err := q.Up(context.Background()); err != nil {
+			t.Fatalf("Up failed: %v", err)
+		}
+		if len(driver.recordedTx) != 1 || len(driver.recorded) != 0 {
+			t.Errorf("recordedTx = %v, recorded = %v; want RecordTx only", driver.recordedTx, driver.recorded)
+		}
+
+		if err := q.Down(context.Background(), 1); err != nil {
+			t.Fatalf("Down failed: %v", err)
+		}
+		if len(driver.removedTx) != 1 || len(driver.removed) != 0 {
+			t.Errorf("removedTx = %v, removed = %v; want RemoveTx only", driver.removedTx, driver.removed)
+		}
+	})
+
+	t.Run("non-transactional DDL records in two steps", func(t *testing.T) {
+		driver := &txTestDriver{transactional: false}
+		q := New(driver)
+		q.MustAdd(noopMigration("001", "first"))
+
+		if q.SupportsTransactionalDDL() {
+			t.Fatal("SupportsTransactionalDDL() = true, want false")
+		}
+		if err := q.Up(context.Background()); err != nil {
+			t.Fatalf("Up failed: %v", err)
+		}
+		if len(driver.recorded) != 1 || len(driver.recordedTx) != 0 {
+			t.Errorf("recorded = %v, recordedTx = %v; want Record only", driver.recorded, driver.recordedTx)
+		}
+	})
+
+	t.Run("RecordTx failure fails the migration", func(t *testing.T) {
+		driver := &txTestDriver{transactional: true, recordTxErr: errors.New("insert failed")}
+		q := New(driver)
+		q.MustAdd(noopMigration("001", "first"))
+
+		err := q.Up(context.Background())
+		if err == nil {
+			t.Fatal("Up succeeded; want error")
+		}
+		if _, ok := q.applied["001"]; ok {
+			t.Error("failed migration must not be cached as applied")
+		}
+	})
+}
+
+// noTxTestDriver supports non-transactional execution on top of txTestDriver.
+type noTxTestDriver struct {
+	txTestDriver
+	noTxCalls int
+}
+
+func (d *noTxTestDriver) ExecNoTx(ctx context.Context, fn func(*sql.Conn) error) error {
+	d.noTxCalls++
+	return fn(nil)
+}
+
+func TestNoTransactionMigration(t *testing.T) {
+	var ran bool
+	m := M{
+		Version:        "001",
+		Name:           "add_index",
+		ManualChecksum: "v1",
+		NoTransaction:  true,
+		UpConnFunc: func(ctx context.Context, conn *sql.Conn) error {
+			ran = true
+			return nil
+		},
+	}
+
+	t.Run("runs outside transaction and records in two steps", func(t *testing.T) {
+		driver := &noTxTestDriver{txTestDriver: txTestDriver{transactional: true}}
+		q := New(driver)
+		q.MustAdd(m)
+
+		if err := q.Up(context.Background()); err != nil {
+			t.Fatalf("Up failed: %v", err)
+		}
+		if !ran || driver.noTxCalls != 1 {
+			t.Errorf("expected migration to run via ExecNoTx (ran=%v, calls=%d)", ran, driver.noTxCalls)
+		}
+		if len(driver.recorded) != 1 || len(driver.recordedTx) != 0 {
+			t.Errorf("recorded = %v, recordedTx = %v; want Record only", driver.recorded, driver.recordedTx)
+		}
+	})
+
+	t.Run("driver without support", func(t *testing.T) {
+		q := New(&txTestDriver{})
+		q.MustAdd(m)
+
+		err := q.Up(context.Background())
+		if !errors.Is(err, ErrNoTxUnsupported) {
+			t.Errorf("Up error = %v, want %v", err, ErrNoTxUnsupported)
+		}
+	})
+
+	t.Run("plan warns about missing transaction", func(t *testing.T) {
+		q := New(&txTestDriver{})
+		q.MustAdd(m)
+
+		plans, err := q.DryRun(context.Background(), "up", 0)
+		if err != nil {
+			t.Fatalf("DryRun failed: %v", err)
+		}
+		if len(plans) != 1 || !plans[0].NoTransaction {
+			t.Fatalf("expected one non-transactional plan, got %+v", plans)
+		}
+
+		found := false
+		for _, w := range plans[0].Warnings {
+			if strings.Contains(w, "outside a transaction") {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("expected non-transactional warning, got %v", plans[0].Warnings)
+		}
+	})
+}
+
+// memDriver keeps applied migrations in memory.
+// Exec invokes fn with a nil transaction, which is enough for Go function migrations.
+type memDriver struct {
+	testDriver
+	applied map[string]Applied
+}
+
+func newMemDriver() *memDriver {
+	return &memDriver{applied: make(map[string]Applied)}
+}
+
+func (d *memDriver) GetApplied(ctx context.Context) ([]Applied, error) {
+	applied := make([]Applied, 0, len(d.applied))
+	for _, a := range d.applied {
+		applied = append(applied, a)
+	}
+	return applied, nil
+}
+func (d *memDriver) Exec(ctx context.Context, isolationLevel sql.IsolationLevel, fn func(*sql.Tx) error) error {
+	return fn(nil)
+}
+func (d *memDriver) Record(ctx context.Context, m *Migration) error {
+	d.applied[m.Version] = Applied{Version: m.Version, Name: m.Name, AppliedAt: time.Now(), Checksum: m.Checksum()}
+	return nil
+}
+func (d *memDriver) Remove(ctx context.Context, version string) error {
+	delete(d.applied, version)
+	return nil
+}
+
+// appliedVersions returns applied versions in natural order.
+func (d *memDriver) appliedVersions() []string {
+	versions := make([]string, 0, len(d.applied))
+	for v := range d.applied {
+		versions = append(versions, v)
+	}
+	sort.Slice(versions, func(i, j int) bool {
+		return naturalsort.Compare(versions[i], versions[j]) < 0
+	})
+	return versions
+}
+
+func TestMigrateTo(t *testing.T) {
+	newQueen := func(driver *memDriver) *Queen {
+		q := New(driver)
+		for _, v := range []string{"1", "2", "10", "11"} {
+			q.MustAdd(noopMigration(v, "m"+v))
+		}
+		return q
+	}
+
+	tests := []struct {
+		name    string
+		applied []string
+		target  string
+		want    []string
+	}{
+		{"up from empty", nil, "10", []string{"1", "2", "10"}},
+		{"down to target", []string{"1", "2", "10", "11"}, "2", []string{"1", "2"}},
+		{"already at target", []string{"1", "2"}, "2", []string{"1", "2"}},
+		{"fills gap and rolls back newer", []string{"1", "11"}, "10", []string{"1", "2", "10"}},
+		{"down to first", []string{"1", "2", "10"}, "1", []string{"1"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			driver := newMemDriver()
+			for _, v := range tt.applied {
+				driver.applied[v] = Applied{Version: v, Checksum: "v1"}
+			}
+			q := newQueen(driver)
+
+			if err := q.MigrateTo(context.Background(), tt.target); err != nil {
+				t.Fatalf("MigrateTo(%s) failed: %v", tt.target, err)
+			}
+			if got := driver.appliedVersions(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("applied = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("unknown version", func(t *testing.T) {
+		q := newQueen(newMemDriver())
+		if err := q.MigrateTo(context.Background(), "5"); !errors.Is(err, ErrMigrationNotFound) {
+			t.Errorf("expected ErrMigrationNotFound, got %v", err)
+		}
+	})
+
+	t.Run("irreversible migration blocks rollback", func(t *testing.T) {
+		driver := newMemDriver()
+		q := New(driver)
+		q.MustAdd(noopMigration("1", "first"))
+		q.MustAdd(M{Version: "2", Name: "second", UpSQL: "SELECT 1"})
+		q.MustAdd(noopMigration("3", "third"))
+		for _, v := range []string{"1", "2", "3"} {
+			driver.applied[v] = Applied{Version: v}
+		}
+
+		if err := q.MigrateTo(context.Background(), "1"); err == nil {
+			t.Fatal("expected error for migration without down")
+		}
+		if got := driver.appliedVersions(); len(got) != 3 {
+			t.Errorf("nothing should be rolled back, applied = %v", got)
+		}
+	})
+}
+
+func TestOrphanedMigrations(t *testing.T) {
+	newQueen := func(config *Config) (*Queen, *memDriver) {
+		driver := newMemDriver()
+		driver.applied["1"] = Applied{Version: "1", Checksum: "v1"}
+		driver.applied["2"] = Applied{Version: "2", Name: "deleted", Checksum: "abc"}
+		q := NewWithConfig(driver, config)
+		q.MustAdd(noopMigration("1", "first"))
+		q.MustAdd(noopMigration("3", "third"))
+		return q, driver
+	}
+
+	t.Run("status reports missing migrations", func(t *testing.T) {
+		q, _ := newQueen(nil)
+
+		statuses, err := q.Status(context.Background())
+		if err != nil {
+			t.Fatalf("Status failed: %v", err)
+		}
+		if len(statuses) != 3 {
+			t.Fatalf("expected 3 statuses, got %d", len(statuses))
+		}
+
+		orphaned := statuses[2]
+		if orphaned.Version != "2" || orphaned.Name != "deleted" || orphaned.Status != StatusOrphaned {
+			t.Errorf("unexpected orphaned status: %+v", orphaned)
+		}
+		if orphaned.Status.String() != "missing" {
+			t.Errorf("String() = %q, want %q", orphaned.Status.String(), "missing")
+		}
+		if orphaned.AppliedAt == nil {
+			t.Error("orphaned migration should have AppliedAt")
+		}
+
+		data, err := json.Marshal(orphaned)
+		if err != nil {
+			t.Fatalf("Marshal failed: %v", err)
+		}
+		// The JSON encoding is part of "migrate status --json" and must stay stable
+		if !strings.Contains(string(data), `"Version":"2"`) || !strings.Contains(string(data), `"Status":4`) {
+			t.Errorf("JSON should keep field names and numeric status, got %s", data)
+		}
+		if strings.Contains(string(data), `"Group"`) {
+			t.Errorf("JSON should omit empty Group, got %s", data)
+		}
+	})
+
+	t.Run("validate passes by default", func(t *testing.T) {
+		q, _ := newQueen(nil)
+		if err := q.Validate(context.Background()); err != nil {
+			t.Errorf("Validate failed: %v", err)
+		}
+	})
+
+	t.Run("validate fails with FailOnOrphaned", func(t *testing.T) {
+		q, _ := newQueen(&Config{FailOnOrphaned: true})
+		if err := q.Validate(context.Background()); !errors.Is(err, ErrOrphanedMigration) {
+			t.Errorf("expected ErrOrphanedMigration, got %v", err)
+		}
+	})
+}
+
+func TestBaseline(t *testing.T) {
+	ctx := context.Background()
+
+	newQueen := func(driver *memDriver) *Queen {
+		q := New(driver)
+		for _, v := range []string{"001", "002", "003", "004"} {
+			q.MustAdd(noopMigration(v, "m"+v))
+		}
+		return q
+	}
+
+	t.Run("records up to version without executing", func(t *testing.T) {
+		driver := newMemDriver()
+		q := New(driver)
+		executed := false
+		q.MustAdd(M{
+			Version:        "001",
+			Name:           "create_users",
+			ManualChecksum: "v1",
+			UpFunc: func(ctx context.Context, tx *sql.Tx) error {
+				executed = true
+				return nil
+			},
+		})
+		q.MustAdd(noopMigration("002", "add_email"))
+
+		recorded, err := q.Baseline(ctx, "001")
+		if err != nil {
+			t.Fatalf("Baseline() error = %v", err)
+		}
+		if executed {
+			t.Error("Baseline() executed the migration")
+		}
+		if len(recorded) != 1 || recorded[0].Version != "001" {
+			t.Errorf("Baseline() recorded %v, want [001]", recorded)
+		}
+		if got := driver.appliedVersions(); !reflect.DeepEqual(got, []string{"001"}) {
+			t.Errorf("applied = %v, want [001]", got)
+		}
+	})
+
+	t.Run("skips applied migrations and is repeatable", func(t *testing.T) {
+		driver := newMemDriver()
+		q := newQueen(driver)
+		if err := q.UpSteps(ctx, 1); err != nil {
+			t.Fatalf("UpSteps() error = %v", err)
+		}
+
+		recorded, err := q.Baseline(ctx, "003")
+		if err != nil {
+			t.Fatalf("Baseline() error = %v", err)
+		}
+		if len(recorded) != 2 {
+			t.Errorf("Baseline() recorded %d migrations, want 2", len(recorded))
+		}
+
+		recorded, err = q.Baseline(ctx, "003")
+		if err != nil {
+			t.Fatalf("second Baseline() error = %v", err)
+		}
+		if len(recorded) != 0 {
+			t.Errorf("second Baseline() recorded %d migrations, want 0", len(recorded))
+		}
+
+		if err := q.Up(ctx); err != nil {
+			t.Fatalf("Up() error = %v", err)
+		}
+		if got, want := driver.appliedVersions(), []string{"001", "002", "003", "004"}; !reflect.DeepEqual(got, want) {
+			t.Errorf("applied = %v, want %v", got, want)
+		}
+	})
+
+	t.Run("unknown version", func(t *testing.T) {
+		q := newQueen(newMemDriver())
+		if _, err := q.Baseline(ctx, "999"); !errors.Is(err, ErrMigrationNotFound) {
+			t.Errorf("Baseline() error = %v, want ErrMigrationNotFound", err)
+		}
+	})
+}
@@ -0,0 +1,190 @@
+package queen
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// MarkApplied records a registered migration as applied without executing it
+// and clears its dirty marker, if any.
+//
+// Use it to repair the tracking table after a migration was applied by hand,
+// or half-failed on a database without transactional DDL (MySQL, ClickHouse)
+// and was then completed manually. For an interrupted rollback (dirty "down")
+// that was reverted by hand, it keeps the migration applied and clears the
+// marker. Runs under the migration lock.
+//
+// Returns ErrMigrationNotFound for unregistered versions and
+// ErrAlreadyApplied if the migration is already recorded and not dirty.
+func (q *Queen) MarkApplied(ctx context.Context, version string) error {
+	m, ok := q.findMigration(version)
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrMigrationNotFound, version)
+	}
+
+	return q.withLock(ctx, func() error {
+		_, dirty := q.dirty[version]
+		if _, applied := q.applied[version]; applied {
+			if !dirty {
+				return fmt.Errorf("%w: %s", ErrAlreadyApplied, version)
+			}
+
+			start := time.Now()
+			err := q.clearDirty(ctx, version)
+			q.audit(ctx, m, "force", start, err)
+			if err != nil {
+				return newMigrationError(m.Version, m.Name, "force", q.getDriverName(), err)
+			}
+			return nil
+		}
+
+		if err := q.recordOnly(ctx, m, "force"); err != nil {
+			return newMigrationError(m.Version, m.Name, "force", q.getDriverName(), err)
+		}
+		if err := q.clearDirty(ctx, version); err != nil {
+			return newMigrationError(m.Version, m.Name, "force", q.getDriverName(), err)
+		}
+		return nil
+	})
+}
+
+// MarkPending removes the tracking row of an applied migration without
+// executing its down migration, so the next Up runs it again, and clears
+// its dirty marker, if any.
+//
+// For an interrupted migration (dirty "up") whose partial changes were
+// reverted by hand, it only clears the marker. The version doesn't have to
+// be registered, which also allows removing orphaned rows of migrations
+// deleted from code. Runs under the migration lock.
+//
+// Returns ErrNotApplied if the version is neither applied nor dirty.
+func (q *Queen) MarkPending(ctx context.Context, version string) error {
+	return q.withLock(ctx, func() error {
+		applied, isApplied := q.applied[version]
+		dirty, isDirty := q.dirty[version]
+		if !isApplied && !isDirty {
+			return fmt.Errorf("%w: %s", ErrNotApplied, version)
+		}
+
+		m, ok := q.findMigration(version)
+		if !ok && isApplied {
+			m = &Migration{Version: applied.Version, Name: applied.Name}
+		} else if !ok {
+			m = &Migration{Version: dirty.Version, Name: dirty.Name}
+		}
+
+		start := time.Now()
+		err := q.markPending(ctx, version, isApplied)
+		q.audit(ctx, m, "unmark", start, err)
+		if err != nil {
+			return newMigrationError(m.Version, m.Name, "unmark", q.getDriverName(), err)
+		}
+
+		q.logger.InfoContext(ctx, "migration marked as pending",
+			"version", m.Version,
+			"name", m.Name)
+
+		return nil
+	})
+}
+
+// markPending removes the tracking row (if applied) and the dirty marker of version.
+func (q *Queen) markPending(ctx context.Context, version string, applied bool) error {
+	if applied {
+		if err := q.driver.Remove(ctx, version); err != nil {
+			return err
+		}
+		delete(q.applied, version)
+	}
+
+	return q.clearDirty(ctx, version)
+}
+
+// UpdateChecksum replaces the stored checksum of an applied migration with
+// the checksum of its current code, accepting an intentional change that
+// would otherwise fail Validate with ErrChecksumMismatch.
+//
+// Drivers implementing ChecksumUpdater update the row in place; for other
+// drivers the row is removed and recorded again, which resets applied_at.
+// Runs under the migration lock.
+//
+// Returns ErrMigrationNotFound for unregistered versions and
+// ErrNotApplied if the migration is not applied.
+func (q *Queen) UpdateChecksum(ctx context.Context, version string) error {
+	m, ok := q.findMigration(version)
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrMigrationNotFound, version)
+	}
+
+	return q.withLock(ctx, func() error {
+		applied, ok := q.applied[version]
+		if !ok {
+			return fmt.Errorf("%w: %s", ErrNotApplied, version)
+		}
+
+		checksum := m.Checksum()
+		if applied.Checksum == checksum {
+			return nil
+		}
+
+		start := time.Now()
+		err := q.updateChecksum(ctx, m)
+		q.audit(ctx, m, "rehash", start, err)
+		if err != nil {
+			return newMigrationError(m.Version, m.Name, "rehash", q.getDriverName(), err)
+		}
+
+		q.logger.InfoContext(ctx, "migration checksum updated",
+			"version", m.Version,
+			"name", m.Name,
+			"old_checksum", applied.Checksum,
+			"new_checksum", checksum)
+
+		applied.Checksum = checksum
+		return nil
+	})
+}
+
+// updateChecksum stores the current checksum of m in the tracking table.
+func (q *Queen) updateChecksum(ctx context.Context, m *Migration) error {
+	if updater, ok := q.driver.(ChecksumUpdater); ok {
+		return updater.UpdateChecksum(ctx, m.Version, m.Checksum())
+	}
+
+	if err := q.driver.Remove(ctx, m.Version); err != nil {
+		return err
+	}
+	return q.driver.Record(ContextWithRecordInfo(ctx, q.recordInfo(time.Now())), m)
+}
+
+// withLock initializes the driver, acquires the migration lock unless
+// Config.SkipLock is set, loads applied migrations and runs fn.
+func (q *Queen) withLock(ctx context.Context, fn func() error) error {
+	if q.driver == nil {
+		return ErrNoDriver
+	}
+
+	if err := q.driver.Init(ctx); err != nil {
+		return err
+	}
+
+	if !q.config.SkipLock {
+		if err := q.driver.Lock(ctx, q.config.LockTimeout); err != nil {
+			return err
+		}
+		defer func() {
+			_ = q.driver.Unlock(context.Background())
+		}()
+
+		lockCtx, stopWatch := q.watchLock(ctx)
+		defer stopWatch()
+		ctx = lockCtx
+	}
+
+	if err := q.loadApplied(ctx); err != nil {
+		return err
+	}
+
+	return lockLostError(ctx, fn())
+}
@@ -0,0 +1,147 @@
+package queen
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// checksumDriver is a memDriver that updates checksums in place.
+type checksumDriver struct {
+	*memDriver
+	updated []string
+}
+
+func (d *checksumDriver) UpdateChecksum(ctx context.Context, version, checksum string) error {
+	a := d.applied[version]
+	a.Checksum = checksum
+	d.applied[version] = a
+	d.updated = append(d.updated, version)
+	return nil
+}
+
+func TestMarkApplied(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	driver := newMemDriver()
+	q := New(driver)
+	q.MustAdd(noopMigration("001", "first"))
+	q.MustAdd(noopMigration("002", "second"))
+
+	if err := q.MarkApplied(ctx, "002"); err != nil {
+		t.Fatalf("MarkApplied() error = %v", err)
+	}
+	if got := driver.appliedVersions(); !reflect.DeepEqual(got, []string{"002"}) {
+		t.Errorf("applied = %v, want [002]", got)
+	}
+
+	if err := q.MarkApplied(ctx, "002"); !errors.Is(err, ErrAlreadyApplied) {
+		t.Errorf("MarkApplied() twice error = %v, want ErrAlreadyApplied", err)
+	}
+	if err := q.MarkApplied(ctx, "999"); !errors.Is(err, ErrMigrationNotFound) {
+		t.Errorf("MarkApplied() unknown error = %v, want ErrMigrationNotFound", err)
+	}
+}
+
+func TestMarkPending(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	driver := newMemDriver()
+	q := New(driver)
+	q.MustAdd(noopMigration("001", "first"))
+	q.MustAdd(noopMigration("002", "second"))
+
+	if err := q.Up(ctx); err != nil {
+		t.Fatalf("Up() error = %v", err)
+	}
+
+	if err := q.MarkPending(ctx, "002"); err != nil {
+		t.Fatalf("MarkPending() error = %v", err)
+	}
+	if got := driver.appliedVersions(); !reflect.DeepEqual(got, []string{"001"}) {
+		t.Errorf("applied = %v, want [001]", got)
+	}
+	if err := q.MarkPending(ctx, "002"); !errors.Is(err, ErrNotApplied) {
+		t.Errorf("MarkPending() twice error = %v, want ErrNotApplied", err)
+	}
+
+	// Orphaned rows can be removed too
+	driver.applied["legacy"] = Applied{Version: "legacy", Name: "deleted_from_code"}
+	if err := q.MarkPending(ctx, "legacy"); err != nil {
+		t.Fatalf("MarkPending() orphaned error = %v", err)
+	}
+	if _, ok := driver.applied["legacy"]; ok {
+		t.Error("orphaned row was not removed")
+	}
+}
+
+func TestUpdateChecksum(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	setup := func(driver Driver, mem *memDriver) *Queen {
+		q := New(driver)
+		m := noopMigration("001", "first")
+		q.MustAdd(m)
+		if err := q.Up(ctx); err != nil {
+			t.Fatalf("Up() error = %v", err)
+		}
+
+		// Simulate an edited migration
+		a := mem.applied["001"]
+		a.Checksum = "stale"
+		mem.applied["001"] = a
+		return q
+	}
+
+	t.Run("in place", func(t *testing.T) {
+		mem := newMemDriver()
+		driver := &checksumDriver{memDriver: mem}
+		q := setup(driver, mem)
+
+		if err := q.Validate(ctx); !errors.Is(err, ErrChecksumMismatch) {
+			t.Fatalf("Validate() error = %v, want ErrChecksumMismatch", err)
+		}
+		if err := q.UpdateChecksum(ctx, "001"); err != nil {
+			t.Fatalf("UpdateChecksum() error = %v", err)
+		}
+		if err := q.Validate(ctx); err != nil {
+			t.Errorf("Validate() after UpdateChecksum error = %v", err)
+		}
+		if !reflect.DeepEqual(driver.updated, []string{"001"}) {
+			t.Errorf("UpdateChecksum called for %v, want [001]", driver.updated)
+		}
+
+		// Matching checksum is a no-op
+		if err := q.UpdateChecksum(ctx, "001"); err != nil {
+			t.Fatalf("second UpdateChecksum() error = %v", err)
+		}
+		if len(driver.updated) != 1 {
+			t.Errorf("UpdateChecksum called %d times, want 1", len(driver.updated))
+		}
+	})
+
+	t.Run("remove and record fallback", func(t *testing.T) {
+		mem := newMemDriver()
+		q := setup(mem, mem)
+
+		if err := q.UpdateChecksum(ctx, "001"); err != nil {
+			t.Fatalf("UpdateChecksum() error = %v", err)
+		}
+		if got := mem.applied["001"].Checksum; got != "v1" {
+			t.Errorf("checksum = %q, want %q", got, "v1")
+		}
+	})
+
+	t.Run("not applied", func(t *testing.T) {
+		q := New(newMemDriver())
+		q.MustAdd(noopMigration("001", "first"))
+		if err := q.UpdateChecksum(ctx, "001"); !errors.Is(err, ErrNotApplied) {
+			t.Errorf("UpdateChecksum() error = %v, want ErrNotApplied", err)
+		}
+	})
+}
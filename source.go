@@ -0,0 +1,476 @@
+package queen
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// SourceIssueKind identifies a problem found in migration files by ValidateSource.
+type SourceIssueKind string
+
+const (
+	// SourceDuplicate indicates several migrations declare the same version,
+	// typically created on parallel branches.
+	SourceDuplicate SourceIssueKind = "duplicate"
+
+	// SourceGap indicates a version is missing from a sequential series (001, 002, 004).
+	SourceGap SourceIssueKind = "gap"
+
+	// SourceMismatch indicates the Version field doesn't match the version in the file name.
+	SourceMismatch SourceIssueKind = "mismatch"
+
+	// SourceUnregistered indicates a migration that is never registered, so it would
+	// silently never run.
+	SourceUnregistered SourceIssueKind = "unregistered"
+
+	// SourceInvalid indicates a file that can't be parsed or would be rejected by LoadFS or Add.
+	SourceInvalid SourceIssueKind = "invalid"
+)
+
+// SourceIssue is a problem found in migration files.
+type SourceIssue struct {
+	Kind    SourceIssueKind `json:"kind"`
+	Version string          `json:"version,omitempty"`
+	Files   []string        `json:"files,omitempty"`
+	Message string          `json:"message"`
+}
+
+// SourceError is returned by ValidateSource when migration files have issues.
+// It matches ErrInvalidSource with errors.Is.
+type SourceError struct {
+	Issues []SourceIssue
+}
+
+func (e *SourceError) Error() string {
+	messages := make([]string, len(e.Issues))
+	for i, issue := range e.Issues {
+		messages[i] = issue.Message
+	}
+	return fmt.Sprintf("%v: %s", ErrInvalidSource, strings.Join(messages, "; "))
+}
+
+func (e *SourceError) Unwrap() error {
+	return ErrInvalidSource
+}
+
+// registerFile is the file that registers the migrations of a directory,
+// as generated by "migrate create".
+const registerFile = "register.go"
+
+// ValidateSource checks the migration files in dir of fsys without compiling
+// or registering them, so that mistakes are caught in CI rather than by
+// ErrVersionConflict at runtime:
+//
+//   - duplicate versions, e.g. 015_add_orders.go and 015_add_invoices.go
+//     created on two branches
+//   - gaps in sequential versions, e.g. 001, 002, 004
+//   - a Version field that doesn't match the version in the file name
+//   - migration variables that are never referenced in register.go
+//   - files that are not valid Go, and SQL files LoadFS would reject
+//
+// Go migrations are queen.M or queen.Migration literals whose Version is a
+// string literal; SQL migrations follow the LoadFS file naming. Gaps are only
+// checked with the sequential naming patterns, or without a naming pattern
+// when all versions are plain numbers. The register.go check is skipped if
+// dir has no register.go. Test files and subdirectories are ignored.
+//
+// It returns a *SourceError listing every issue, or nil if there are none.
+func ValidateSource(fsys fs.FS, dir string, naming *NamingConfig) error {
+	src, err := scanSource(fsys, dir)
+	if err != nil {
+		return err
+	}
+	return src.check(naming, nil)
+}
+
+// ValidateSource checks the migration files in dir of fsys like the
+// ValidateSource function, using the configured NamingConfig. Migrations
+// found in the files but not registered with q are reported as unregistered.
+//
+//	q := queen.New(driver)
+//	migrations.Register(q)
+//	if err := q.ValidateSource(os.DirFS("migrations"), "."); err != nil {
+//	    log.Fatal(err)
+//	}
+func (q *Queen) ValidateSource(fsys fs.FS, dir string) error {
+	src, err := scanSource(fsys, dir)
+	if err != nil {
+		return err
+	}
+
+	registered := make(map[string]bool, len(q.migrations))
+	for _, m := range q.migrations {
+		registered[m.Version] = true
+	}
+	return src.check(q.config.Naming, registered)
+}
+
+// sourceMigration is a migration declared in a file.
+type sourceMigration struct {
+	version  string   // tracked version, including the group
+	local    string   // version within the group
+	files    []string // up and down files of SQL migrations
+	variable string   // package variable of Go migrations, if any
+}
+
+// source holds the migrations found in a directory.
+type source struct {
+	migrations []*sourceMigration
+	issues     []SourceIssue
+
+	// references holds the identifiers used in register.go, nil without register.go.
+	references map[string]bool
+}
+
+func scanSource(fsys fs.FS, dir string) (*source, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read migrations directory %s: %w", dir, err)
+	}
+
+	src := &source{}
+	sqlMigrations := make(map[string]*sourceMigration)
+
+	for _, entry := range entries {
+		name := entry.Name()
+		filename := path.Join(dir, name)
+
+		switch {
+		case entry.IsDir():
+			continue
+
+		case isSQLMigrationFile(name):
+			version, migrationName, _, err := parseSQLFileName(name)
+			if err != nil {
+				src.invalid(filename, err)
+				continue
+			}
+
+			key := version + "_" + migrationName
+			if m, ok := sqlMigrations[key]; ok {
+				m.files = append(m.files, filename)
+				continue
+			}
+			m := &sourceMigration{version: version, local: version, files: []string{filename}}
+			sqlMigrations[key] = m
+			src.migrations = append(src.migrations, m)
+
+		case strings.HasSuffix(name, ".go") && !strings.HasSuffix(name, "_test.go"):
+			content, err := fs.ReadFile(fsys, filename)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read migration file %s: %w", filename, err)
+			}
+			src.scanGoFile(filename, content)
+		}
+	}
+
+	return src, nil
+}
+
+func (s *source) invalid(filename string, err error) {
+	s.issues = append(s.issues, SourceIssue{
+		Kind:    SourceInvalid,
+		Files:   []string{filename},
+		Message: fmt.Sprintf("%s: %v", filename, err),
+	})
+}
+
+// scanGoFile collects the migration literals of a Go file, and the
+// identifiers referenced by register.go.
+func (s *source) scanGoFile(filename string, content []byte) {
+	file, err := parser.ParseFile(token.NewFileSet(), filename, content, 0)
+	if err != nil {
+		s.invalid(filename, err)
+		return
+	}
+
+	base := path.Base(filename)
+	if base == registerFile {
+		s.references = make(map[string]bool)
+		ast.Inspect(file, func(n ast.Node) bool {
+			if ident, ok := n.(*ast.Ident); ok {
+				s.references[ident.Name] = true
+			}
+			return true
+		})
+	}
+
+	pkg := queenImportName(file)
+	if pkg == "" {
+		return
+	}
+
+	// Package variables holding a migration, to check them against register.go
+	variables := make(map[*ast.CompositeLit]string)
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.VAR {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			vs := spec.(*ast.ValueSpec)
+			for i, value := range vs.Values {
+				if unary, ok := value.(*ast.UnaryExpr); ok && unary.Op == token.AND {
+					value = unary.X
+				}
+				if lit, ok := value.(*ast.CompositeLit); ok && i < len(vs.Names) {
+					variables[lit] = vs.Names[i].Name
+				}
+			}
+		}
+	}
+
+	var found []*sourceMigration
+	add := func(lit *ast.CompositeLit) {
+		fields := stringFields(lit)
+		if _, ok := fields["Version"]; !ok {
+			return
+		}
+
+		m := Migration{Version: fields["Version"], Group: fields["Group"]}
+		if err := qualifyGroup(&m); err != nil {
+			s.invalid(filename, err)
+			return
+		}
+		_, local := splitGroup(m.Version)
+		found = append(found, &sourceMigration{
+			version:  m.Version,
+			local:    local,
+			files:    []string{filename},
+			variable: variables[lit],
+		})
+	}
+
+	ast.Inspect(file, func(n ast.Node) bool {
+		lit, ok := n.(*ast.CompositeLit)
+		if !ok {
+			return true
+		}
+
+		switch typ := lit.Type.(type) {
+		case *ast.SelectorExpr:
+			if isMigrationType(typ, pkg) {
+				add(lit)
+				return false
+			}
+		case *ast.ArrayType:
+			// []queen.M{{Version: "001", ...}, ...}
+			if sel, ok := typ.Elt.(*ast.SelectorExpr); ok && isMigrationType(sel, pkg) {
+				for _, elt := range lit.Elts {
+					if elem, ok := elt.(*ast.CompositeLit); ok && elem.Type == nil {
+						add(elem)
+					}
+				}
+				return false
+			}
+		}
+		return true
+	})
+
+	// A file created by "migrate create" declares one migration and is
+	// named after its version.
+	if len(found) == 1 && base != registerFile {
+		m := found[0]
+		if name := strings.TrimSuffix(base, ".go"); name != m.local && !strings.HasPrefix(name, m.local+"_") {
+			s.issues = append(s.issues, SourceIssue{
+				Kind:    SourceMismatch,
+				Version: m.version,
+				Files:   []string{filename},
+				Message: fmt.Sprintf("%s: Version %q does not match the file name", filename, m.local),
+			})
+		}
+	}
+
+	s.migrations = append(s.migrations, found...)
+}
+
+// check reports duplicates, gaps and unregistered migrations, in addition to
+// the issues found while scanning. registered holds the versions registered
+// with Queen, or nil if unknown.
+func (s *source) check(naming *NamingConfig, registered map[string]bool) error {
+	issues := append([]SourceIssue(nil), s.issues...)
+
+	byVersion := make(map[string][]*sourceMigration)
+	for _, m := range s.migrations {
+		byVersion[m.version] = append(byVersion[m.version], m)
+
+		switch {
+		case m.variable != "" && s.references != nil && !s.references[m.variable]:
+			issues = append(issues, SourceIssue{
+				Kind:    SourceUnregistered,
+				Version: m.version,
+				Files:   m.files,
+				Message: fmt.Sprintf("%s: %s is not referenced in %s", m.files[0], m.variable, registerFile),
+			})
+		case registered != nil && !registered[m.version]:
+			issues = append(issues, SourceIssue{
+				Kind:    SourceUnregistered,
+				Version: m.version,
+				Files:   m.files,
+				Message: fmt.Sprintf("%s: migration %s is not registered", m.files[0], m.version),
+			})
+		}
+	}
+
+	versions := make([]string, 0, len(byVersion))
+	for version := range byVersion {
+		versions = append(versions, version)
+	}
+	sort.Slice(versions, func(i, j int) bool {
+		return compareVersions(versions[i], versions[j]) < 0
+	})
+
+	for _, version := range versions {
+		if declared := byVersion[version]; len(declared) > 1 {
+			var files []string
+			for _, m := range declared {
+				files = append(files, m.files...)
+			}
+			issues = append(issues, SourceIssue{
+				Kind:    SourceDuplicate,
+				Version: version,
+				Files:   files,
+				Message: fmt.Sprintf("version %s is declared %d times: %s", version, len(declared), strings.Join(files, ", ")),
+			})
+		}
+	}
+
+	issues = append(issues, findGaps(versions, naming)...)
+
+	if len(issues) == 0 {
+		return nil
+	}
+
+	// Issues of the same version are reported together, file errors first
+	sort.SliceStable(issues, func(i, j int) bool {
+		return compareVersions(issues[i].Version, issues[j].Version) < 0
+	})
+	return &SourceError{Issues: issues}
+}
+
+// findGaps reports missing versions in sequential series, per group.
+// versions must be sorted.
+func findGaps(versions []string, naming *NamingConfig) []SourceIssue {
+	groups := make(map[string][]string)
+	var order []string
+	for _, version := range versions {
+		group, local := splitGroup(version)
+		if _, ok := groups[group]; !ok {
+			order = append(order, group)
+		}
+		groups[group] = append(groups[group], local)
+	}
+
+	var issues []SourceIssue
+	for _, group := range order {
+		locals := groups[group]
+		if !isSequential(locals, naming) {
+			continue
+		}
+
+		for i := 1; i < len(locals); i++ {
+			prev, _ := strconv.Atoi(locals[i-1])
+			next, _ := strconv.Atoi(locals[i])
+			if next-prev <= 1 {
+				continue
+			}
+
+			width := 0
+			if strings.HasPrefix(locals[i-1], "0") {
+				width = len(locals[i-1])
+			}
+			first := qualify(group, fmt.Sprintf("%0*d", width, prev+1))
+			missing := "version " + first + " is"
+			if next-prev > 2 {
+				missing = fmt.Sprintf("versions %s to %s are", first, qualify(group, fmt.Sprintf("%0*d", width, next-1)))
+			}
+
+			issues = append(issues, SourceIssue{
+				Kind:    SourceGap,
+				Version: first,
+				Message: fmt.Sprintf("%s missing between %s and %s",
+					missing, qualify(group, locals[i-1]), qualify(group, locals[i])),
+			})
+		}
+	}
+	return issues
+}
+
+// isSequential reports whether versions are numbered sequentially. Without a
+// naming pattern, plain numbers shorter than a timestamp are sequential.
+func isSequential(versions []string, naming *NamingConfig) bool {
+	pattern := NamingPatternNone
+	if naming != nil {
+		pattern = naming.Pattern
+	}
+	if pattern != NamingPatternNone && pattern != NamingPatternSequential && pattern != NamingPatternSequentialPadded {
+		return false
+	}
+
+	for _, version := range versions {
+		if _, err := strconv.ParseUint(version, 10, 64); err != nil {
+			return false
+		}
+		if pattern == NamingPatternNone && len(version) >= len(TimestampLayout) {
+			return false
+		}
+	}
+	return true
+}
+
+func qualify(group, version string) string {
+	if group == "" {
+		return version
+	}
+	return group + groupSeparator + version
+}
+
+// queenImportName returns the name the queen package is imported as, or ""
+// if file doesn't import it.
+func queenImportName(file *ast.File) string {
+	for _, imp := range file.Imports {
+		if p, _ := strconv.Unquote(imp.Path.Value); p != "github.com/honeynil/queen" {
+			continue
+		}
+		if imp.Name != nil {
+			return imp.Name.Name
+		}
+		return "queen"
+	}
+	return ""
+}
+
+func isMigrationType(sel *ast.SelectorExpr, pkg string) bool {
+	ident, ok := sel.X.(*ast.Ident)
+	return ok && ident.Name == pkg && (sel.Sel.Name == "M" || sel.Sel.Name == "Migration")
+}
+
+// stringFields returns the fields of a struct literal set to string literals.
+func stringFields(lit *ast.CompositeLit) map[string]string {
+	fields := make(map[string]string)
+	for _, elt := range lit.Elts {
+		kv, ok := elt.(*ast.KeyValueExpr)
+		if !ok {
+			continue
+		}
+		key, ok := kv.Key.(*ast.Ident)
+		if !ok {
+			continue
+		}
+		value, ok := kv.Value.(*ast.BasicLit)
+		if !ok || value.Kind != token.STRING {
+			continue
+		}
+		if s, err := strconv.Unquote(value.Value); err == nil {
+			fields[key.Name] = s
+		}
+	}
+	return fields
+}
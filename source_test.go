@@ -0,0 +1,200 @@
+package queen
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"testing/fstest"
+)
+
+func goMigrationFile(variable, version string) *fstest.MapFile {
+	return &fstest.MapFile{Data: []byte(`package migrations
+
+import "github.com/honeynil/queen"
+
+var ` + variable + ` = queen.M{
+	Version: "` + version + `",
+	Name:    "migration",
+	UpSQL:   "SELECT 1",
+}
+`)}
+}
+
+func registerGoFile(variables ...string) *fstest.MapFile {
+	body := ""
+	for _, v := range variables {
+		body += "\tq.MustAdd(" + v + ")\n"
+	}
+	return &fstest.MapFile{Data: []byte(`package migrations
+
+import "github.com/honeynil/queen"
+
+func Register(q *queen.Queen) {
+` + body + `}
+`)}
+}
+
+// sourceIssues returns the kind and version of each issue of a *SourceError.
+func sourceIssues(t *testing.T, err error) []string {
+	t.Helper()
+
+	if err == nil {
+		return nil
+	}
+	var sourceErr *SourceError
+	if !errors.As(err, &sourceErr) {
+		t.Fatalf("error = %v, want *SourceError", err)
+	}
+	if !errors.Is(err, ErrInvalidSource) {
+		t.Errorf("error %v should match ErrInvalidSource", err)
+	}
+
+	var issues []string
+	for _, issue := range sourceErr.Issues {
+		issues = append(issues, string(issue.Kind)+" "+issue.Version)
+	}
+	return issues
+}
+
+func TestValidateSource(t *testing.T) {
+	tests := []struct {
+		name   string
+		files  fstest.MapFS
+		naming *NamingConfig
+		want   []string
+	}{
+		{
+			name: "valid",
+			files: fstest.MapFS{
+				"m/001_create_users.go":     goMigrationFile("Migration001", "001"),
+				"m/002_add_email.go":        goMigrationFile("Migration002", "002"),
+				"m/003_add_index.up.sql":    {Data: []byte("CREATE INDEX idx ON users (email)")},
+				"m/003_add_index.down.sql":  {Data: []byte("DROP INDEX idx")},
+				"m/register.go":             registerGoFile("Migration001", "Migration002"),
+				"m/migrations_test.go":      {Data: []byte("package migrations")},
+				"m/helpers.go":              {Data: []byte("package migrations\n\nfunc helper() {}\n")},
+				"m/README.md":               {Data: []byte("docs")},
+				"m/seed.sql":                {Data: []byte("INSERT INTO users VALUES (1)")},
+				"m/fixtures/001_fixture.go": goMigrationFile("Fixture", "001"),
+			},
+		},
+		{
+			name: "duplicate versions from two branches",
+			files: fstest.MapFS{
+				"m/001_create_users.go":    goMigrationFile("Migration001", "001"),
+				"m/002_add_orders.go":      goMigrationFile("Migration002AddOrders", "002"),
+				"m/002_add_invoices.go":    goMigrationFile("Migration002AddInvoices", "002"),
+				"m/002_add_coupons.up.sql": {Data: []byte("CREATE TABLE coupons (id INT)")},
+			},
+			want: []string{"duplicate 002"},
+		},
+		{
+			name: "gaps",
+			files: fstest.MapFS{
+				"m/001_a.go": goMigrationFile("Migration001", "001"),
+				"m/002_b.go": goMigrationFile("Migration002", "002"),
+				"m/004_c.go": goMigrationFile("Migration004", "004"),
+				"m/008_d.go": goMigrationFile("Migration008", "008"),
+			},
+			want: []string{"gap 003", "gap 005"},
+		},
+		{
+			name: "no gaps with the timestamp pattern",
+			files: fstest.MapFS{
+				"m/20260101120000_a.go": goMigrationFile("MigrationA", "20260101120000"),
+				"m/20260315090000_b.go": goMigrationFile("MigrationB", "20260315090000"),
+			},
+			naming: &NamingConfig{Pattern: NamingPatternTimestamp},
+		},
+		{
+			name: "no gaps with the semver pattern",
+			files: fstest.MapFS{
+				"m/1_a.go": goMigrationFile("MigrationA", "1"),
+				"m/5_b.go": goMigrationFile("MigrationB", "5"),
+			},
+			naming: &NamingConfig{Pattern: NamingPatternSemver},
+		},
+		{
+			name: "Version does not match the file name",
+			files: fstest.MapFS{
+				"m/001_a.go": goMigrationFile("Migration001", "001"),
+				"m/002_b.go": goMigrationFile("Migration002", "001"),
+			},
+			want: []string{"mismatch 001", "duplicate 001"},
+		},
+		{
+			name: "not referenced in register.go",
+			files: fstest.MapFS{
+				"m/001_a.go":    goMigrationFile("Migration001", "001"),
+				"m/002_b.go":    goMigrationFile("Migration002", "002"),
+				"m/register.go": registerGoFile("Migration001"),
+			},
+			want: []string{"unregistered 002"},
+		},
+		{
+			name: "gaps per group",
+			files: fstest.MapFS{
+				"m/001_a.go": {Data: []byte(`package migrations
+
+import q "github.com/honeynil/queen"
+
+var All = []q.M{
+	{Group: "billing", Version: "001", Name: "a"},
+	{Group: "billing", Version: "003", Name: "b"},
+	{Version: "auth/001", Name: "c"},
+	{Version: "auth/002", Name: "d"},
+}
+`)},
+			},
+			want: []string{"gap billing/002"},
+		},
+		{
+			name: "invalid files",
+			files: fstest.MapFS{
+				"m/001_a.go":     {Data: []byte("package migrations\n\nvar x = ")},
+				"m/Bad.up.sql":   {Data: []byte("SELECT 1")},
+				"m/002_b.go":     goMigrationFile("Migration002", "002"),
+				"m/003_c.up.sql": {Data: []byte("SELECT 1")},
+			},
+			want: []string{"invalid ", "invalid "},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := sourceIssues(t, ValidateSource(tt.files, "m", tt.naming))
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("issues = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateSourceMissingDir(t *testing.T) {
+	err := ValidateSource(fstest.MapFS{}, "migrations", nil)
+	if err == nil || errors.Is(err, ErrInvalidSource) {
+		t.Errorf("error = %v, want a read error", err)
+	}
+}
+
+func TestQueen_ValidateSource(t *testing.T) {
+	files := fstest.MapFS{
+		"m/001_a.go":     goMigrationFile("Migration001", "001"),
+		"m/002_b.up.sql": {Data: []byte("SELECT 1")},
+	}
+
+	q := New(newMemDriver())
+	q.MustAdd(noopMigration("001", "a"))
+
+	got := sourceIssues(t, q.ValidateSource(files, "m"))
+	if want := []string{"unregistered 002"}; !slices.Equal(got, want) {
+		t.Errorf("issues = %q, want %q", got, want)
+	}
+
+	if err := LoadFS(q, files, "m"); err != nil {
+		t.Fatalf("LoadFS() error = %v", err)
+	}
+	if err := q.ValidateSource(files, "m"); err != nil {
+		t.Errorf("ValidateSource() error = %v", err)
+	}
+}
@@ -15,6 +15,19 @@ const (
 	// StatusModified indicates the migration has been applied,
 	// but its content has changed (checksum mismatch).
 	StatusModified
+
+	// StatusOutOfOrder indicates the migration has not been applied yet,
+	// but a migration with a newer version already has (e.g. a late-merged branch).
+	StatusOutOfOrder
+
+	// StatusOrphaned indicates the migration is recorded as applied in the database,
+	// but is no longer registered in code (e.g. it was deleted).
+	StatusOrphaned
+
+	// StatusDirty indicates the migration started but never finished, so the
+	// database may be partially migrated. Up and Down refuse to run until the
+	// marker is resolved with MarkApplied or MarkPending.
+	StatusDirty
 )
 
 // String returns a human-readable representation of the status.
@@ -26,6 +39,12 @@ func (s Status) String() string {
 		return "applied"
 	case StatusModified:
 		return "modified"
+	case StatusOutOfOrder:
+		return "out-of-order"
+	case StatusOrphaned:
+		return "missing"
+	case StatusDirty:
+		return "dirty"
 	default:
 		return "unknown"
 	}
@@ -40,7 +59,11 @@ type MigrationStatus struct {
 	// Name is the human-readable name of the migration.
 	Name string
 
-	// Status indicates whether the migration is pending, applied, or modified.
+	// Group is the migration group, empty for ungrouped migrations.
+	Group string `json:",omitempty"`
+
+	// Status indicates whether the migration is pending, applied, modified, out-of-order,
+	// missing from code (orphaned), or dirty (interrupted).
 	Status Status
 
 	// AppliedAt is when the migration was applied (nil if not applied).
@@ -50,6 +73,7 @@ type MigrationStatus struct {
 	Checksum string
 
 	// HasRollback indicates if the migration has a down migration.
+	// Always false for orphaned migrations.
 	HasRollback bool
 
 	// Destructive indicates if the down migration contains destructive operations.
@@ -82,7 +106,7 @@ type MigrationPlan struct {
 	// Direction indicates the migration direction: "up" or "down".
 	Direction string `json:"direction"`
 
-	// Status indicates whether the migration is pending, applied, or modified.
+	// Status indicates whether the migration is pending, applied, modified, or out-of-order.
 	Status string `json:"status"`
 
 	// Type indicates the migration type: "sql", "go-func", or "mixed".
@@ -102,6 +126,9 @@ type MigrationPlan struct {
 	// Checksum is the current checksum of the migration.
 	Checksum string `json:"checksum"`
 
+	// NoTransaction indicates the migration runs outside a transaction.
+	NoTransaction bool `json:"no_transaction,omitempty"`
+
 	// Warnings contains any warnings about this migration.
 	// Examples: "No rollback defined", "Destructive operation", etc.
 	Warnings []string `json:"warnings,omitempty"`
@@ -2,9 +2,16 @@ package queen
 
 import "regexp"
 
-
 // IsValidMigrationName checks if a migration name is valid.
 func IsValidMigrationName(name string) bool {
 	matched, _ := regexp.MatchString(`^[a-z0-9_]+$`, name)
 	return matched
-}
\ No newline at end of file
+}
+
+// IsValidMigrationVersion checks if a migration version is valid.
+// Versions allow the same characters as names plus dots and dashes,
+// so semantic versions such as "1.2.0" or "v1.0.0-rc1" are accepted.
+func IsValidMigrationVersion(version string) bool {
+	matched, _ := regexp.MatchString(`^[a-z0-9_.-]+$`, version)
+	return matched
+}